@@ -0,0 +1,158 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/aiven/aiven-go-client"
+)
+
+// clientRequest calls an Aiven API endpoint that is not yet covered by aiven-go-client, using
+// the credentials of the configured client. The response body is decoded into rsp when it is
+// not nil, API errors are returned as aiven.Error so that aiven.IsNotFound works as usual.
+func clientRequest(client *aiven.Client, method, path string, req, rsp interface{}) error {
+	var body []byte
+	if req != nil {
+		var err error
+		if body, err = json.Marshal(req); err != nil {
+			return err
+		}
+	}
+
+	r, err := http.NewRequest(method, clientRequestURL(path), bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("User-Agent", client.UserAgent)
+	r.Header.Set("Authorization", "aivenv1 "+client.APIKey)
+
+	httpClient := client.Client
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	res, err := httpClient.Do(r)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			log.Printf("[WARN] cannot close response body: %s", err)
+		}
+	}()
+
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return aiven.Error{Message: string(b), Status: res.StatusCode}
+	}
+
+	if rsp == nil || len(b) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(b, rsp); err != nil {
+		return fmt.Errorf("cannot decode response of %s %s: %s", method, path, err)
+	}
+
+	return nil
+}
+
+// clientRequestURL builds the URL of an API path the same way as aiven-go-client does
+func clientRequestURL(path string) string {
+	base := "https://api.aiven.io"
+	if v, ok := os.LookupEnv("AIVEN_WEB_URL"); ok {
+		base = strings.TrimSuffix(v, "/")
+	}
+
+	return base + "/v1" + path
+}
+
+// clientRequestPath joins escaped path segments into an API path
+func clientRequestPath(segments ...string) string {
+	var b strings.Builder
+	for _, s := range segments {
+		b.WriteString("/")
+		b.WriteString(url.PathEscape(s))
+	}
+
+	return b.String()
+}
+
+// serviceDetails holds the fields of the service API response that are not part of aiven.Service
+type serviceDetails struct {
+	DiskSpaceMB int               `json:"disk_space_mb"`
+	Tags        map[string]string `json:"tags"`
+	Backups     []serviceBackup   `json:"backups"`
+	Maintenance struct {
+		Updates []serviceMaintenanceUpdate `json:"updates"`
+	} `json:"maintenance"`
+}
+
+// serviceBackup is a backup of a service, aiven-go-client does not decode the name of a backup
+type serviceBackup struct {
+	BackupName string `json:"backup_name"`
+	BackupTime string `json:"backup_time"`
+	DataSize   int    `json:"data_size"`
+}
+
+// getService returns a service together with the fields that aiven-go-client does not decode,
+// both are read from the same response
+func getService(client *aiven.Client, projectName, serviceName string) (*aiven.Service, *serviceDetails, error) {
+	var r struct {
+		Service json.RawMessage `json:"service"`
+	}
+
+	path := clientRequestPath("project", projectName, "service", serviceName)
+	if err := clientRequest(client, http.MethodGet, path, nil, &r); err != nil {
+		return nil, nil, err
+	}
+
+	service, details, err := decodeService(r.Service)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot decode service %s: %s", serviceName, err)
+	}
+
+	return service, details, nil
+}
+
+// decodeService decodes a service of an API response both as aiven.Service and serviceDetails
+func decodeService(raw json.RawMessage) (*aiven.Service, *serviceDetails, error) {
+	var service aiven.Service
+	if err := json.Unmarshal(raw, &service); err != nil {
+		return nil, nil, err
+	}
+
+	var details serviceDetails
+	if err := json.Unmarshal(raw, &details); err != nil {
+		return nil, nil, err
+	}
+
+	return &service, &details, nil
+}
+
+// getServiceDetails returns the fields of a service that aiven-go-client does not decode
+func getServiceDetails(client *aiven.Client, projectName, serviceName string) (*serviceDetails, error) {
+	var r struct {
+		Service serviceDetails `json:"service"`
+	}
+
+	path := clientRequestPath("project", projectName, "service", serviceName)
+	if err := clientRequest(client, http.MethodGet, path, nil, &r); err != nil {
+		return nil, err
+	}
+
+	return &r.Service, nil
+}
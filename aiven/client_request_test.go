@@ -0,0 +1,97 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+)
+
+// testClientRequestServer returns a client sending its requests to handler, the requests are
+// redirected by the transport of the client so that no process wide setting is changed and
+// the tests can run in parallel. The returned function stops the server.
+func testClientRequestServer(t *testing.T, handler http.HandlerFunc) (*aiven.Client, func()) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "aivenv1 token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		handler(w, r)
+	}))
+
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transport := &testClientRequestTransport{url: serverURL, next: server.Client().Transport}
+	return &aiven.Client{APIKey: "token", Client: &http.Client{Transport: transport}}, server.Close
+}
+
+// testClientRequestTransport sends requests to the test server whatever their host is
+type testClientRequestTransport struct {
+	url  *url.URL
+	next http.RoundTripper
+}
+
+func (t *testClientRequestTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.URL.Scheme = t.url.Scheme
+	r.URL.Host = t.url.Host
+	r.Host = t.url.Host
+
+	return t.next.RoundTrip(r)
+}
+
+func Test_clientRequest(t *testing.T) {
+	client, done := testClientRequestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/v1/project/my%2Fproject":
+			_, _ = w.Write([]byte(`{"project": {"project_name": "my/project"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "not found"}`))
+		}
+	})
+	defer done()
+
+	var r struct {
+		Project struct {
+			Name string `json:"project_name"`
+		} `json:"project"`
+	}
+	if err := clientRequest(client, http.MethodGet, clientRequestPath("project", "my/project"), nil, &r); err != nil {
+		t.Fatalf("clientRequest() error = %v", err)
+	}
+	if r.Project.Name != "my/project" {
+		t.Errorf("clientRequest() project = %v, want my/project", r.Project.Name)
+	}
+
+	if err := clientRequest(client, http.MethodGet, clientRequestPath("project", "unknown"), nil, nil); !aiven.IsNotFound(err) {
+		t.Errorf("clientRequest() error = %v, want not found", err)
+	}
+}
+
+func Test_decodeService(t *testing.T) {
+	service, details, err := decodeService(json.RawMessage(`{"service_name": "test-pg", "service_type": "pg", "state": "RUNNING",
+		"disk_space_mb": 81920, "tags": {"team": "data"},
+		"maintenance": {"dow": "monday", "time": "10:00:00", "updates": [{"description": "update"}]}}`))
+	if err != nil {
+		t.Fatalf("decodeService() error = %v", err)
+	}
+	if service.Name != "test-pg" || service.Type != "pg" || service.MaintenanceWindow.DayOfWeek != "monday" {
+		t.Errorf("decodeService() service = %+v", service)
+	}
+	if details.DiskSpaceMB != 81920 || details.Tags["team"] != "data" || len(details.Maintenance.Updates) != 1 {
+		t.Errorf("decodeService() details = %+v", details)
+	}
+
+	if _, _, err := decodeService(json.RawMessage(`{"disk_space_mb": "large"}`)); err == nil {
+		t.Errorf("decodeService() error = nil, want an error for an invalid service")
+	}
+}
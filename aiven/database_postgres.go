@@ -0,0 +1,241 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+// databaseDefaultPrivilegeTypes are the privileges that can be granted per object type of
+// PostgreSQL default privileges
+var databaseDefaultPrivilegeTypes = map[string][]string{
+	"TABLES":    {"ALL", "SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER"},
+	"SEQUENCES": {"ALL", "SELECT", "UPDATE", "USAGE"},
+	"FUNCTIONS": {"ALL", "EXECUTE"},
+	"TYPES":     {"ALL", "USAGE"},
+}
+
+// databaseDefaultPrivilege is a default_privileges block, Privileges are sorted
+type databaseDefaultPrivilege struct {
+	Role       string
+	Schema     string
+	ObjectType string
+	Privileges []string
+}
+
+// key identifies a default privilege with its privileges
+func (p databaseDefaultPrivilege) key() string {
+	return strings.Join([]string{p.Role, p.Schema, p.ObjectType, strings.Join(p.Privileges, ",")}, "\x00")
+}
+
+// databasePGConfiguration is the PostgreSQL configuration of a database
+type databasePGConfiguration struct {
+	Owner             string
+	Extensions        []string
+	DefaultPrivileges []databaseDefaultPrivilege
+}
+
+// empty tells whether none of the PostgreSQL fields are used
+func (c databasePGConfiguration) empty() bool {
+	return c.Owner == "" && len(c.Extensions) == 0 && len(c.DefaultPrivileges) == 0
+}
+
+// expandDatabasePGConfiguration expands the PostgreSQL fields of a database
+func expandDatabasePGConfiguration(owner interface{}, extensions interface{}, defaultPrivileges interface{}) databasePGConfiguration {
+	c := databasePGConfiguration{Owner: owner.(string)}
+
+	if s, ok := extensions.(*schema.Set); ok {
+		c.Extensions = flattenToString(s.List())
+	}
+	if s, ok := defaultPrivileges.(*schema.Set); ok {
+		for _, v := range s.List() {
+			p := v.(map[string]interface{})
+			privileges := flattenToString(p["privileges"].(*schema.Set).List())
+			sort.Strings(privileges)
+			c.DefaultPrivileges = append(c.DefaultPrivileges, databaseDefaultPrivilege{
+				Role:       p["role"].(string),
+				Schema:     p["schema"].(string),
+				ObjectType: p["object_type"].(string),
+				Privileges: privileges,
+			})
+		}
+	}
+
+	sort.Strings(c.Extensions)
+	sort.Slice(c.DefaultPrivileges, func(i, j int) bool { return c.DefaultPrivileges[i].key() < c.DefaultPrivileges[j].key() })
+
+	return c
+}
+
+// validateDatabasePGConfiguration checks the privileges of default privileges against their
+// object type
+func validateDatabasePGConfiguration(c databasePGConfiguration) error {
+	for _, p := range c.DefaultPrivileges {
+		for _, privilege := range p.Privileges {
+			if !serviceUserAccessControlHasString(databaseDefaultPrivilegeTypes[p.ObjectType], privilege) {
+				return fmt.Errorf("default privileges of %q on %s: privilege %q is not supported, expected one of %s",
+					p.Role, p.ObjectType, privilege, strings.Join(databaseDefaultPrivilegeTypes[p.ObjectType], ", "))
+			}
+		}
+	}
+
+	return nil
+}
+
+// databasePGStatements returns the statements that change the PostgreSQL configuration of a
+// database from old to new. The owner is changed first, then extensions are dropped and created
+// and default privileges revoked and granted. Default privileges are set for the owner, so all of
+// them are granted again when the owner changes.
+func databasePGStatements(database string, old, new databasePGConfiguration) ([]serviceStatement, error) {
+	if err := validateDatabasePGConfiguration(new); err != nil {
+		return nil, err
+	}
+
+	var statements []serviceStatement
+	add := func(format string, a ...interface{}) {
+		statements = append(statements, serviceStatement{database, fmt.Sprintf(format, a...)})
+	}
+
+	if new.Owner != "" && new.Owner != old.Owner {
+		add("GRANT %s TO CURRENT_USER", pq.QuoteIdentifier(new.Owner))
+		add("ALTER DATABASE %s OWNER TO %s", pq.QuoteIdentifier(database), pq.QuoteIdentifier(new.Owner))
+	}
+
+	for _, e := range old.Extensions {
+		if !serviceUserAccessControlHasString(new.Extensions, e) {
+			add("DROP EXTENSION IF EXISTS %s", pq.QuoteIdentifier(e))
+		}
+	}
+	for _, e := range new.Extensions {
+		if !serviceUserAccessControlHasString(old.Extensions, e) {
+			add("CREATE EXTENSION IF NOT EXISTS %s", pq.QuoteIdentifier(e))
+		}
+	}
+
+	ownerChanged := new.Owner != old.Owner
+	for _, p := range old.DefaultPrivileges {
+		if ownerChanged || !databaseHasDefaultPrivilege(new.DefaultPrivileges, p) {
+			add("%s", databaseDefaultPrivilegeStatement(old.Owner, p, true))
+		}
+	}
+	for _, p := range new.DefaultPrivileges {
+		if ownerChanged || !databaseHasDefaultPrivilege(old.DefaultPrivileges, p) {
+			add("%s", databaseDefaultPrivilegeStatement(new.Owner, p, false))
+		}
+	}
+
+	return statements, nil
+}
+
+func databaseHasDefaultPrivilege(privileges []databaseDefaultPrivilege, p databaseDefaultPrivilege) bool {
+	for _, o := range privileges {
+		if o.key() == p.key() {
+			return true
+		}
+	}
+	return false
+}
+
+// databaseDefaultPrivilegeStatement returns the statement of a default privilege, the objects
+// created by the current user are used when owner is empty
+func databaseDefaultPrivilegeStatement(owner string, p databaseDefaultPrivilege, revoke bool) string {
+	statement := "ALTER DEFAULT PRIVILEGES"
+	if owner != "" {
+		statement += " FOR ROLE " + pq.QuoteIdentifier(owner)
+	}
+	statement += " IN SCHEMA " + pq.QuoteIdentifier(p.Schema)
+
+	privileges := serviceUserAccessControlPrivilegeList(p.Privileges, "ALL PRIVILEGES")
+	if revoke {
+		return fmt.Sprintf("%s REVOKE %s ON %s FROM %s", statement, privileges, p.ObjectType, pq.QuoteIdentifier(p.Role))
+	}
+	return fmt.Sprintf("%s GRANT %s ON %s TO %s", statement, privileges, p.ObjectType, pq.QuoteIdentifier(p.Role))
+}
+
+// resourceDatabaseApplyPGConfiguration changes the PostgreSQL configuration of a database from
+// the old to the new values of the resource
+func resourceDatabaseApplyPGConfiguration(ctx context.Context, d *schema.ResourceData, m interface{}, old, new databasePGConfiguration) error {
+	if old.empty() && new.empty() {
+		return nil
+	}
+
+	projectName, serviceName, databaseName := splitResourceID3(d.Id())
+	conn, err := getServiceConnection(m.(*providerMeta).client, projectName, serviceName)
+	if err != nil {
+		return err
+	}
+	if conn.ServiceType != "pg" {
+		return fmt.Errorf("owner, extensions and default_privileges are only supported for PostgreSQL databases, not %q", conn.ServiceType)
+	}
+
+	statements, err := databasePGStatements(databaseName, old, new)
+	if err != nil {
+		return err
+	}
+
+	if err := execServiceStatements(ctx, conn, statements); err != nil {
+		return fmt.Errorf("cannot configure database %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// copyDatabasePGConfigurationFromDatabaseToTerraform reads the owner and extensions of a
+// PostgreSQL database when they are used by the resource, other databases and the data source,
+// which does not have the fields, are not connected to
+func copyDatabasePGConfigurationFromDatabaseToTerraform(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	owner, _ := d.Get("owner").(string)
+	extensions, _ := d.Get("extensions").(*schema.Set)
+	if owner == "" && (extensions == nil || extensions.Len() == 0) {
+		return nil
+	}
+
+	projectName, serviceName, databaseName := splitResourceID3(d.Id())
+	conn, err := getServiceConnection(m.(*providerMeta).client, projectName, serviceName)
+	if err != nil {
+		return err
+	}
+	if conn.ServiceType != "pg" {
+		return nil
+	}
+
+	var dbOwner string
+	var dbExtensions []string
+	err = withServicePostgres(conn, databaseName, func(db *sql.DB) error {
+		if err := db.QueryRowContext(ctx,
+			"SELECT pg_get_userbyid(datdba) FROM pg_database WHERE datname = current_database()").Scan(&dbOwner); err != nil {
+			return err
+		}
+
+		// plpgsql is installed in every database
+		rows, err := db.QueryContext(ctx, "SELECT extname FROM pg_extension WHERE extname <> 'plpgsql'")
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var e string
+			if err := rows.Scan(&e); err != nil {
+				return err
+			}
+			dbExtensions = append(dbExtensions, e)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return fmt.Errorf("cannot read configuration of database %s: %s", d.Id(), err)
+	}
+
+	if err := d.Set("owner", dbOwner); err != nil {
+		return err
+	}
+
+	return d.Set("extensions", dbExtensions)
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func Test_expandDatabasePGConfiguration(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, aivenDatabaseSchema, map[string]interface{}{
+		"project":       "test-project",
+		"service_name":  "test-service",
+		"database_name": "test-db",
+		"owner":         "app",
+		"extensions":    []interface{}{"postgis", "pg_stat_statements"},
+		"default_privileges": []interface{}{
+			map[string]interface{}{"role": "reader", "object_type": "TABLES", "privileges": []interface{}{"SELECT"}},
+			map[string]interface{}{"role": "app", "schema": "jobs", "object_type": "SEQUENCES", "privileges": []interface{}{"USAGE", "SELECT"}},
+		},
+	})
+
+	want := databasePGConfiguration{
+		Owner:      "app",
+		Extensions: []string{"pg_stat_statements", "postgis"},
+		DefaultPrivileges: []databaseDefaultPrivilege{
+			{Role: "app", Schema: "jobs", ObjectType: "SEQUENCES", Privileges: []string{"SELECT", "USAGE"}},
+			{Role: "reader", Schema: "public", ObjectType: "TABLES", Privileges: []string{"SELECT"}},
+		},
+	}
+	got := expandDatabasePGConfiguration(d.Get("owner"), d.Get("extensions"), d.Get("default_privileges"))
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expandDatabasePGConfiguration() = %+v, want %+v", got, want)
+	}
+}
+
+func Test_databasePGStatements(t *testing.T) {
+	readTables := databaseDefaultPrivilege{Role: "reader", Schema: "public", ObjectType: "TABLES", Privileges: []string{"SELECT"}}
+	useSequences := databaseDefaultPrivilege{Role: "app", Schema: "public", ObjectType: "SEQUENCES", Privileges: []string{"ALL"}}
+
+	tests := []struct {
+		name    string
+		old     databasePGConfiguration
+		new     databasePGConfiguration
+		want    []serviceStatement
+		wantErr bool
+	}{
+		{
+			"create",
+			databasePGConfiguration{},
+			databasePGConfiguration{Owner: "app", Extensions: []string{"pg_stat_statements"}, DefaultPrivileges: []databaseDefaultPrivilege{readTables}},
+			[]serviceStatement{
+				{"db1", `GRANT "app" TO CURRENT_USER`},
+				{"db1", `ALTER DATABASE "db1" OWNER TO "app"`},
+				{"db1", `CREATE EXTENSION IF NOT EXISTS "pg_stat_statements"`},
+				{"db1", `ALTER DEFAULT PRIVILEGES FOR ROLE "app" IN SCHEMA "public" GRANT SELECT ON TABLES TO "reader"`},
+			},
+			false,
+		},
+		{
+			"without-owner",
+			databasePGConfiguration{},
+			databasePGConfiguration{DefaultPrivileges: []databaseDefaultPrivilege{useSequences}},
+			[]serviceStatement{
+				{"db1", `ALTER DEFAULT PRIVILEGES IN SCHEMA "public" GRANT ALL PRIVILEGES ON SEQUENCES TO "app"`},
+			},
+			false,
+		},
+		{
+			"update",
+			databasePGConfiguration{Owner: "app", Extensions: []string{"pg_stat_statements", "postgis"}, DefaultPrivileges: []databaseDefaultPrivilege{readTables}},
+			databasePGConfiguration{Owner: "app", Extensions: []string{"pg_stat_statements", "timescaledb"}, DefaultPrivileges: []databaseDefaultPrivilege{useSequences}},
+			[]serviceStatement{
+				{"db1", `DROP EXTENSION IF EXISTS "postgis"`},
+				{"db1", `CREATE EXTENSION IF NOT EXISTS "timescaledb"`},
+				{"db1", `ALTER DEFAULT PRIVILEGES FOR ROLE "app" IN SCHEMA "public" REVOKE SELECT ON TABLES FROM "reader"`},
+				{"db1", `ALTER DEFAULT PRIVILEGES FOR ROLE "app" IN SCHEMA "public" GRANT ALL PRIVILEGES ON SEQUENCES TO "app"`},
+			},
+			false,
+		},
+		{
+			"owner-changed",
+			databasePGConfiguration{Owner: "app", DefaultPrivileges: []databaseDefaultPrivilege{readTables}},
+			databasePGConfiguration{Owner: "app2", DefaultPrivileges: []databaseDefaultPrivilege{readTables}},
+			[]serviceStatement{
+				{"db1", `GRANT "app2" TO CURRENT_USER`},
+				{"db1", `ALTER DATABASE "db1" OWNER TO "app2"`},
+				{"db1", `ALTER DEFAULT PRIVILEGES FOR ROLE "app" IN SCHEMA "public" REVOKE SELECT ON TABLES FROM "reader"`},
+				{"db1", `ALTER DEFAULT PRIVILEGES FOR ROLE "app2" IN SCHEMA "public" GRANT SELECT ON TABLES TO "reader"`},
+			},
+			false,
+		},
+		{
+			"unchanged",
+			databasePGConfiguration{Owner: "app", Extensions: []string{"postgis"}, DefaultPrivileges: []databaseDefaultPrivilege{readTables}},
+			databasePGConfiguration{Owner: "app", Extensions: []string{"postgis"}, DefaultPrivileges: []databaseDefaultPrivilege{readTables}},
+			nil,
+			false,
+		},
+		{
+			"privilege-of-another-object-type",
+			databasePGConfiguration{},
+			databasePGConfiguration{DefaultPrivileges: []databaseDefaultPrivilege{
+				{Role: "app", Schema: "public", ObjectType: "FUNCTIONS", Privileges: []string{"SELECT"}},
+			}},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := databasePGStatements("db1", tt.old, tt.new)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("databasePGStatements() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("databasePGStatements() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
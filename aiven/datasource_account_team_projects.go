@@ -0,0 +1,110 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceAccountTeamProjects() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceAccountTeamProjectsRead,
+		Description: "The Account Team Projects data source provides all the projects the teams of an account are granted access to, for example to review who can access a project.",
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The unique account id",
+			},
+			"project_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the grants of this project",
+			},
+			"project_grants": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Project grants of the teams ordered by project and team name",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"project_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Project name",
+						},
+						"team_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Account team id",
+						},
+						"team_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Account team name",
+						},
+						"team_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: complex("The access the team has to the project.").possibleValues("admin", "developer", "operator", "read_only").build(),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func datasourceAccountTeamProjectsRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	accountId := d.Get("account_id").(string)
+	projectName := d.Get("project_name").(string)
+
+	r, err := client.AccountTeams.List(accountId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	grants := make([]map[string]interface{}, 0)
+	for _, t := range r.Teams {
+		rp, err := client.AccountTeamProjects.List(accountId, t.Id)
+		if err != nil {
+			return diag.Errorf("cannot get the projects of account team %s: %s", t.Name, err)
+		}
+
+		for _, p := range rp.Projects {
+			if projectName != "" && p.ProjectName != projectName {
+				continue
+			}
+
+			grants = append(grants, map[string]interface{}{
+				"project_name": p.ProjectName,
+				"team_id":      t.Id,
+				"team_name":    t.Name,
+				"team_type":    p.TeamType,
+			})
+		}
+	}
+
+	sort.Slice(grants, func(i, j int) bool {
+		if grants[i]["project_name"] != grants[j]["project_name"] {
+			return grants[i]["project_name"].(string) < grants[j]["project_name"].(string)
+		}
+		return grants[i]["team_name"].(string) < grants[j]["team_name"].(string)
+	})
+
+	if projectName != "" {
+		d.SetId(buildResourceID(accountId, projectName))
+	} else {
+		d.SetId(accountId)
+	}
+
+	if err := d.Set("project_grants", grants); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
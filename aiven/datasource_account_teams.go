@@ -0,0 +1,155 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"sort"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceAccountTeams() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceAccountTeamsRead,
+		Description: "The Account Teams data source provides all the teams of an account together with their members, for example to review the access to an account.",
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The unique account id",
+			},
+			"teams": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Teams of the account ordered by name",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"team_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Account team id",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Account team name",
+						},
+						"create_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Time of creation",
+						},
+						"members": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Members of the team ordered by email address",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"user_id": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "User id",
+									},
+									"user_email": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "User email address",
+									},
+									"real_name": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "User real name",
+									},
+								},
+							},
+						},
+						"member_emails": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Email addresses of the members of the team",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"pending_user_emails": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Email addresses of the users invited to the team that did not accept their invitation yet",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func datasourceAccountTeamsRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	accountId := d.Get("account_id").(string)
+
+	r, err := client.AccountTeams.List(accountId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	sort.Slice(r.Teams, func(i, j int) bool {
+		return r.Teams[i].Name < r.Teams[j].Name
+	})
+
+	teams := make([]map[string]interface{}, 0, len(r.Teams))
+	for _, t := range r.Teams {
+		members, invites, err := getAccountTeamMembers(client, accountId, t.Id)
+		if err != nil {
+			return diag.Errorf("cannot get the members of account team %s: %s", t.Name, err)
+		}
+
+		teams = append(teams, flattenAccountTeamWithMembers(t, members, invites))
+	}
+
+	d.SetId(accountId)
+
+	if err := d.Set("teams", teams); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func flattenAccountTeamWithMembers(t aiven.AccountTeam, members []aiven.AccountTeamMember, invites []aiven.AccountTeamInvite) map[string]interface{} {
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].UserEmail < members[j].UserEmail
+	})
+
+	flattenedMembers := make([]map[string]interface{}, 0, len(members))
+	emails := make([]string, 0, len(members))
+	for _, member := range members {
+		flattenedMembers = append(flattenedMembers, map[string]interface{}{
+			"user_id":    member.UserId,
+			"user_email": member.UserEmail,
+			"real_name":  member.RealName,
+		})
+		emails = append(emails, member.UserEmail)
+	}
+
+	pending := make([]string, 0, len(invites))
+	for _, i := range invites {
+		pending = append(pending, i.UserEmail)
+	}
+	sort.Strings(pending)
+
+	var createTime string
+	if t.CreateTime != nil {
+		createTime = t.CreateTime.String()
+	}
+
+	return map[string]interface{}{
+		"team_id":             t.Id,
+		"name":                t.Name,
+		"create_time":         createTime,
+		"members":             flattenedMembers,
+		"member_emails":       emails,
+		"pending_user_emails": pending,
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAivenAccountTeamsDataSource_basic(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAccountTeamsDataSource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.aiven_account_teams.teams", "teams.#", "1"),
+					resource.TestCheckResourceAttrPair("data.aiven_account_teams.teams", "teams.0.team_id", "aiven_account_team.foo", "team_id"),
+					resource.TestCheckResourceAttr("data.aiven_account_teams.teams", "teams.0.pending_user_emails.#", "1"),
+					resource.TestCheckResourceAttr("data.aiven_account_team_projects.grants", "project_grants.#", "1"),
+					resource.TestCheckResourceAttr("data.aiven_account_team_projects.grants", "project_grants.0.team_type", "developer"),
+					resource.TestCheckResourceAttr("data.aiven_account_team_projects.grants", "project_grants.0.project_name", fmt.Sprintf("test-acc-pr-%s", rName)),
+				),
+			},
+		},
+	})
+}
+
+func testAccAccountTeamsDataSource(name string) string {
+	return fmt.Sprintf(`
+		resource "aiven_account" "foo" {
+			name = "test-acc-ac-%s"
+		}
+
+		resource "aiven_account_team" "foo" {
+			account_id = aiven_account.foo.account_id
+			name = "test-acc-team-%s"
+		}
+
+		resource "aiven_account_team_members" "foo" {
+			account_id = aiven_account_team.foo.account_id
+			team_id = aiven_account_team.foo.team_id
+			user_emails = ["ivan.savciuc+%s@aiven.fi"]
+		}
+
+		resource "aiven_project" "foo" {
+			project = "test-acc-pr-%s"
+			account_id = aiven_account_team.foo.account_id
+		}
+
+		resource "aiven_account_team_project" "foo" {
+			account_id = aiven_account.foo.account_id
+			team_id = aiven_account_team.foo.team_id
+			project_name = aiven_project.foo.project
+			team_type = "developer"
+		}
+
+		data "aiven_account_teams" "teams" {
+			account_id = aiven_account.foo.account_id
+
+			depends_on = [aiven_account_team_members.foo]
+		}
+
+		data "aiven_account_team_projects" "grants" {
+			account_id = aiven_account.foo.account_id
+			project_name = aiven_project.foo.project
+
+			depends_on = [aiven_account_team_project.foo]
+		}
+		`, name, name, name, name)
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"net/http"
+	"sort"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// billingGroupInvoice is an invoice of a billing group, the invoices are not covered by
+// aiven-go-client
+type billingGroupInvoice struct {
+	InvoiceNumber string `json:"invoice_number"`
+	PeriodBegin   string `json:"period_begin"`
+	PeriodEnd     string `json:"period_end"`
+	Currency      string `json:"currency"`
+	TotalIncVAT   string `json:"total_inc_vat"`
+	TotalVATZero  string `json:"total_vat_zero"`
+	State         string `json:"state"`
+}
+
+func datasourceBillingGroupInvoices() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceBillingGroupInvoicesRead,
+		Description: "The Billing Group Invoices data source provides the invoices of a billing group, for example to reconcile the costs of the projects of the group.",
+		Schema: map[string]*schema.Schema{
+			"billing_group_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Billing group id",
+			},
+			"state": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"estimate", "mailed", "paid", "unpaid", "accrual", "consolidated", "due", "failed_credit_card_charge", "failed_no_credit_card", "partner_metering", "uncollectible", "waived"}, false),
+				Description:  "Only return the invoices in this state, for example `estimate` for the invoice of the current period or `paid`",
+			},
+			"invoices": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Invoices of the billing group, newest first",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"invoice_number": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Invoice number identifying the invoice",
+						},
+						"period_begin": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Start of the billing period of the invoice",
+						},
+						"period_end": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "End of the billing period of the invoice",
+						},
+						"currency": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Currency of the invoice",
+						},
+						"total_inc_vat": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Total amount of the invoice including VAT",
+						},
+						"total_vat_zero": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Total amount of the invoice excluding VAT",
+						},
+						"state": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "State of the invoice",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func datasourceBillingGroupInvoicesRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	billingGroupID := d.Get("billing_group_id").(string)
+	state := d.Get("state").(string)
+
+	invoices, err := getBillingGroupInvoices(client, billingGroupID)
+	if err != nil {
+		return diag.Errorf("cannot get invoices of billing group %s: %s", billingGroupID, err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(invoices))
+	for _, i := range invoices {
+		if state != "" && i.State != state {
+			continue
+		}
+
+		result = append(result, map[string]interface{}{
+			"invoice_number": i.InvoiceNumber,
+			"period_begin":   i.PeriodBegin,
+			"period_end":     i.PeriodEnd,
+			"currency":       i.Currency,
+			"total_inc_vat":  i.TotalIncVAT,
+			"total_vat_zero": i.TotalVATZero,
+			"state":          i.State,
+		})
+	}
+
+	if state != "" {
+		d.SetId(buildResourceID(billingGroupID, state))
+	} else {
+		d.SetId(billingGroupID)
+	}
+
+	if err := d.Set("invoices", result); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// getBillingGroupInvoices returns the invoices of a billing group ordered by their period,
+// newest first
+func getBillingGroupInvoices(client *aiven.Client, billingGroupID string) ([]billingGroupInvoice, error) {
+	var r struct {
+		Invoices []billingGroupInvoice `json:"invoices"`
+	}
+
+	if err := clientRequest(client, http.MethodGet, clientRequestPath("billing-group", billingGroupID, "invoice"), nil, &r); err != nil {
+		return nil, err
+	}
+
+	sortBillingGroupInvoices(r.Invoices)
+
+	return r.Invoices, nil
+}
+
+// sortBillingGroupInvoices sorts invoices by their billing period, the newest first
+func sortBillingGroupInvoices(invoices []billingGroupInvoice) {
+	sort.SliceStable(invoices, func(i, j int) bool {
+		return invoices[i].PeriodBegin > invoices[j].PeriodBegin
+	})
+}
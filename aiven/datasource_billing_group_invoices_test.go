@@ -0,0 +1,60 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAivenBillingGroupInvoicesDataSource_basic(t *testing.T) {
+	datasourceName := "data.aiven_billing_group_invoices.invoices"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenBillingGroupResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBillingGroupInvoicesDataSource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(datasourceName, "billing_group_id", "aiven_billing_group.foo", "id"),
+					resource.TestCheckResourceAttrSet(datasourceName, "invoices.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccBillingGroupInvoicesDataSource(name string) string {
+	return fmt.Sprintf(`
+		resource "aiven_billing_group" "foo" {
+			name = "test-acc-bg-%s"
+		}
+
+		data "aiven_billing_group_invoices" "invoices" {
+			billing_group_id = aiven_billing_group.foo.id
+		}
+		`, name)
+}
+
+func Test_sortBillingGroupInvoices(t *testing.T) {
+	invoices := []billingGroupInvoice{
+		{InvoiceNumber: "bg1-1", PeriodBegin: "2021-09-01T00:00:00Z"},
+		{InvoiceNumber: "bg1-3", PeriodBegin: "2021-11-01T00:00:00Z"},
+		{InvoiceNumber: "bg1-2", PeriodBegin: "2021-10-01T00:00:00Z"},
+	}
+
+	sortBillingGroupInvoices(invoices)
+
+	var numbers []string
+	for _, i := range invoices {
+		numbers = append(numbers, i.InvoiceNumber)
+	}
+	if fmt.Sprint(numbers) != "[bg1-3 bg1-2 bg1-1]" {
+		t.Errorf("sortBillingGroupInvoices() = %v, want the newest invoice first", numbers)
+	}
+}
@@ -5,22 +5,27 @@ package aiven
 import (
 	"context"
 
-	"github.com/aiven/aiven-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func datasourceDatabase() *schema.Resource {
+	s := resourceSchemaAsDatasourceSchema(aivenDatabaseSchema,
+		"project", "service_name", "database_name")
+	// the PostgreSQL configuration is read over a connection to the service, not from the API
+	for _, k := range []string{"owner", "extensions", "default_privileges"} {
+		delete(s, k)
+	}
+
 	return &schema.Resource{
 		ReadContext: datasourceDatabaseRead,
 		Description: "The Database data source provides information about the existing Aiven Database.",
-		Schema: resourceSchemaAsDatasourceSchema(aivenDatabaseSchema,
-			"project", "service_name", "database_name"),
+		Schema:      s,
 	}
 }
 
 func datasourceDatabaseRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
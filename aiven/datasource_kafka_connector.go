@@ -5,7 +5,6 @@ package aiven
 import (
 	"context"
 
-	"github.com/aiven/aiven-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -24,7 +23,7 @@ func datasourceKafkaConnectorRead(ctx context.Context, d *schema.ResourceData, m
 	serviceName := d.Get("service_name").(string)
 	connectorName := d.Get("connector_name").(string)
 
-	cons, err := m.(*aiven.Client).KafkaConnectors.List(projectName, serviceName)
+	cons, err := m.(*providerMeta).client.KafkaConnectors.List(projectName, serviceName)
 	if err != nil {
 		return diag.FromErr(err)
 	}
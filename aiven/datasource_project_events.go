@@ -0,0 +1,175 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func datasourceProjectEvents() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceProjectEventsRead,
+		Description: "The Project Events data source provides the recent entries of the event log of a project, for example to investigate drift or to keep an audit trail.",
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Project name",
+			},
+			"start_time": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+				Description:  "Only return the events at or after this time, in RFC 3339 format",
+			},
+			"end_time": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+				Description:  "Only return the events before this time, in RFC 3339 format",
+			},
+			"actor": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the events of this actor, for example the email address of a user",
+			},
+			"event_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the events of this type, for example `service_create`",
+			},
+			"service_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the events of this service",
+			},
+			"events": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Events of the project, newest first",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Time of the event",
+						},
+						"actor": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Initiator of the event",
+						},
+						"event_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Type of the event",
+						},
+						"event_desc": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Description of the event",
+						},
+						"service_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Service the event concerns, empty for project events",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// projectEventsFilter selects the project events returned by the data source
+type projectEventsFilter struct {
+	startTime   *time.Time
+	endTime     *time.Time
+	actor       string
+	eventType   string
+	serviceName string
+}
+
+func datasourceProjectEventsRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	projectName := d.Get("project").(string)
+
+	filter := projectEventsFilter{
+		actor:       d.Get("actor").(string),
+		eventType:   d.Get("event_type").(string),
+		serviceName: d.Get("service_name").(string),
+	}
+	if v, ok := d.GetOk("start_time"); ok {
+		t, _ := time.Parse(time.RFC3339, v.(string))
+		filter.startTime = &t
+	}
+	if v, ok := d.GetOk("end_time"); ok {
+		t, _ := time.Parse(time.RFC3339, v.(string))
+		filter.endTime = &t
+	}
+
+	events, err := client.Projects.GetEventLog(projectName)
+	if err != nil {
+		return diag.Errorf("cannot get event log of project %s: %s", projectName, err)
+	}
+
+	result, err := filterProjectEvents(events, filter)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(projectName)
+
+	if err := d.Set("events", result); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// filterProjectEvents returns the events matching a filter
+func filterProjectEvents(events []*aiven.ProjectEvent, filter projectEventsFilter) ([]map[string]interface{}, error) {
+	result := make([]map[string]interface{}, 0, len(events))
+
+	for _, e := range events {
+		if filter.actor != "" && e.Actor != filter.actor {
+			continue
+		}
+		if filter.eventType != "" && e.EventType != filter.eventType {
+			continue
+		}
+		if filter.serviceName != "" && e.ServiceName != filter.serviceName {
+			continue
+		}
+
+		if filter.startTime != nil || filter.endTime != nil {
+			t, err := time.Parse(time.RFC3339, e.Time)
+			if err != nil {
+				return nil, fmt.Errorf("cannot parse time of project event %q: %s", e.Time, err)
+			}
+			if filter.startTime != nil && t.Before(*filter.startTime) {
+				continue
+			}
+			if filter.endTime != nil && !t.Before(*filter.endTime) {
+				continue
+			}
+		}
+
+		result = append(result, map[string]interface{}{
+			"time":         e.Time,
+			"actor":        e.Actor,
+			"event_type":   e.EventType,
+			"event_desc":   e.EventDesc,
+			"service_name": e.ServiceName,
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAivenProjectEventsDataSource_basic(t *testing.T) {
+	datasourceName := "data.aiven_project_events.events"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProjectEventsDataSource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(datasourceName, "events.0.time"),
+					resource.TestCheckResourceAttr(datasourceName, "events.0.event_type", "project_create"),
+				),
+			},
+		},
+	})
+}
+
+func testAccProjectEventsDataSource(name string) string {
+	return fmt.Sprintf(`
+		resource "aiven_project" "foo" {
+			project = "test-acc-pr-%s"
+			default_cloud = "aws-eu-west-2"
+			billing_currency = "EUR"
+		}
+
+		data "aiven_project_events" "events" {
+			project = aiven_project.foo.project
+			event_type = "project_create"
+
+			depends_on = [aiven_project.foo]
+		}
+		`, name)
+}
+
+func Test_filterProjectEvents(t *testing.T) {
+	events := []*aiven.ProjectEvent{
+		{Actor: "alice@example.com", EventType: "service_create", ServiceName: "pg", Time: "2021-11-03T10:00:00Z"},
+		{Actor: "bob@example.com", EventType: "service_update", ServiceName: "pg", Time: "2021-11-02T10:00:00Z"},
+		{Actor: "alice@example.com", EventType: "project_update", Time: "2021-11-01T10:00:00Z"},
+	}
+	at := func(s string) *time.Time {
+		t, _ := time.Parse(time.RFC3339, s)
+		return &t
+	}
+
+	tests := []struct {
+		name   string
+		filter projectEventsFilter
+		want   []string
+	}{
+		{"all", projectEventsFilter{}, []string{"service_create", "service_update", "project_update"}},
+		{"actor", projectEventsFilter{actor: "alice@example.com"}, []string{"service_create", "project_update"}},
+		{"service", projectEventsFilter{serviceName: "pg", eventType: "service_update"}, []string{"service_update"}},
+		{"start time", projectEventsFilter{startTime: at("2021-11-02T10:00:00Z")}, []string{"service_create", "service_update"}},
+		{"time range", projectEventsFilter{startTime: at("2021-11-01T00:00:00Z"), endTime: at("2021-11-02T10:00:00Z")}, []string{"project_update"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := filterProjectEvents(events, tt.filter)
+			if err != nil {
+				t.Fatalf("filterProjectEvents() error = %v", err)
+			}
+
+			var types []string
+			for _, e := range got {
+				types = append(types, e["event_type"].(string))
+			}
+			if fmt.Sprint(types) != fmt.Sprint(tt.want) {
+				t.Errorf("filterProjectEvents() = %v, want %v", types, tt.want)
+			}
+		})
+	}
+}
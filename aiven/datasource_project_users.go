@@ -0,0 +1,131 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"sort"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceProjectUsers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceProjectUsersRead,
+		Description: "The Project Users data source provides all the users of a project and the users invited to it, including the users with access through account teams.",
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Project name",
+			},
+			"users": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Users of the project ordered by email address, followed by the pending invitations",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"email": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Email address of the user",
+						},
+						"real_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Real name of the user",
+						},
+						"member_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: complex("Project membership type.").possibleValues("admin", "developer", "operator", "read_only").build(),
+						},
+						"accepted": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the user accepted the invitation to the project, `false` for pending invitations",
+						},
+						"team_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Account team the user has access through, empty for direct project members",
+						},
+						"team_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the account team the user has access through",
+						},
+						"billing_contact": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the user is a billing contact of the project",
+						},
+						"invited_by_user_email": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Email address of the user that sent the pending invitation",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func datasourceProjectUsersRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	projectName := d.Get("project").(string)
+
+	users, invitations, err := client.ProjectUsers.List(projectName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(projectName)
+
+	if err := d.Set("users", flattenProjectUsers(users, invitations)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func flattenProjectUsers(users []*aiven.ProjectUser, invitations []*aiven.ProjectInvitation) []map[string]interface{} {
+	sort.SliceStable(users, func(i, j int) bool {
+		return users[i].Email < users[j].Email
+	})
+	sort.SliceStable(invitations, func(i, j int) bool {
+		return invitations[i].UserEmail < invitations[j].UserEmail
+	})
+
+	result := make([]map[string]interface{}, 0, len(users)+len(invitations))
+	for _, u := range users {
+		result = append(result, map[string]interface{}{
+			"email":                 u.Email,
+			"real_name":             u.RealName,
+			"member_type":           u.MemberType,
+			"accepted":              true,
+			"team_id":               u.TeamId,
+			"team_name":             u.TeamName,
+			"billing_contact":       u.BillingContact,
+			"invited_by_user_email": "",
+		})
+	}
+
+	for _, i := range invitations {
+		result = append(result, map[string]interface{}{
+			"email":                 i.UserEmail,
+			"real_name":             "",
+			"member_type":           i.MemberType,
+			"accepted":              false,
+			"team_id":               "",
+			"team_name":             "",
+			"billing_contact":       false,
+			"invited_by_user_email": i.InvitingUserEmail,
+		})
+	}
+
+	return result
+}
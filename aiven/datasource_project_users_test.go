@@ -0,0 +1,103 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAivenProjectUsersDataSource_basic(t *testing.T) {
+	datasourceName := "data.aiven_project_users.users"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProjectUsersDataSource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs(datasourceName, "users.*", map[string]string{
+						"email":       fmt.Sprintf("ivan.savciuc+%s@aiven.fi", rName),
+						"member_type": "developer",
+						"accepted":    "false",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccProjectUsersDataSource(name string) string {
+	return fmt.Sprintf(`
+		resource "aiven_project" "foo" {
+			project = "test-acc-pr-%s"
+			default_cloud = "aws-eu-west-2"
+			billing_currency = "EUR"
+		}
+
+		resource "aiven_project_user" "bar" {
+			project = aiven_project.foo.project
+			email = "ivan.savciuc+%s@aiven.fi"
+			member_type = "developer"
+		}
+
+		data "aiven_project_users" "users" {
+			project = aiven_project_user.bar.project
+
+			depends_on = [aiven_project_user.bar]
+		}
+		`, name, name)
+}
+
+func Test_flattenProjectUsers(t *testing.T) {
+	users := []*aiven.ProjectUser{
+		{Email: "bob@example.com", MemberType: "developer", TeamId: "t1", TeamName: "developers"},
+		{Email: "alice@example.com", MemberType: "admin", BillingContact: true},
+	}
+	invitations := []*aiven.ProjectInvitation{
+		{UserEmail: "carol@example.com", InvitingUserEmail: "alice@example.com", MemberType: "read_only"},
+	}
+
+	want := []map[string]interface{}{
+		{
+			"email":                 "alice@example.com",
+			"real_name":             "",
+			"member_type":           "admin",
+			"accepted":              true,
+			"team_id":               "",
+			"team_name":             "",
+			"billing_contact":       true,
+			"invited_by_user_email": "",
+		},
+		{
+			"email":                 "bob@example.com",
+			"real_name":             "",
+			"member_type":           "developer",
+			"accepted":              true,
+			"team_id":               "t1",
+			"team_name":             "developers",
+			"billing_contact":       false,
+			"invited_by_user_email": "",
+		},
+		{
+			"email":                 "carol@example.com",
+			"real_name":             "",
+			"member_type":           "read_only",
+			"accepted":              false,
+			"team_id":               "",
+			"team_name":             "",
+			"billing_contact":       false,
+			"invited_by_user_email": "alice@example.com",
+		},
+	}
+
+	if got := flattenProjectUsers(users, invitations); !reflect.DeepEqual(got, want) {
+		t.Errorf("flattenProjectUsers() = %v, want %v", got, want)
+	}
+}
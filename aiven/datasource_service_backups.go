@@ -0,0 +1,132 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceServiceBackups() *schema.Resource {
+	return &schema.Resource{
+		Description: "The Service Backups data source provides the list of backups of an existing Aiven service.",
+		ReadContext: datasourceServiceBackupsRead,
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Project name",
+			},
+			"service_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Service name",
+			},
+			"backups": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Backups of the service, ordered from the oldest to the latest",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"backup_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the backup, it is given as `backup_name` of `aiven_service_fork` to restore a service type that supports restoring a named backup",
+						},
+						"backup_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Time of the backup in RFC3339 format",
+						},
+						"data_size": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Size of the backup in bytes",
+						},
+					},
+				},
+			},
+			"oldest_backup_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time of the oldest backup, the start of the window a point-in-time restore can target",
+			},
+			"latest_backup_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time of the latest backup",
+			},
+		},
+	}
+}
+
+func datasourceServiceBackupsRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+
+	_, details, err := getService(client, projectName, serviceName)
+	if err != nil {
+		return diag.Errorf("service %s/%s not found: %s", projectName, serviceName, err)
+	}
+
+	d.SetId(buildResourceID(projectName, serviceName))
+
+	backups := flattenServiceBackups(details.Backups)
+	if err := d.Set("backups", backups); err != nil {
+		return diag.FromErr(err)
+	}
+
+	var oldest, latest string
+	if len(backups) > 0 {
+		oldest = backups[0]["backup_time"].(string)
+		latest = backups[len(backups)-1]["backup_time"].(string)
+	}
+	if err := d.Set("oldest_backup_time", oldest); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("latest_backup_time", latest); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// flattenServiceBackups returns the backups of a service ordered by their time
+func flattenServiceBackups(backups []serviceBackup) []map[string]interface{} {
+	sorted := make([]serviceBackup, len(backups))
+	copy(sorted, backups)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return serviceBackupTime(sorted[i].BackupTime).Before(serviceBackupTime(sorted[j].BackupTime))
+	})
+
+	result := make([]map[string]interface{}, 0, len(sorted))
+	for _, b := range sorted {
+		backupTime := b.BackupTime
+		if t := serviceBackupTime(b.BackupTime); !t.IsZero() {
+			backupTime = t.UTC().Format(time.RFC3339)
+		}
+
+		result = append(result, map[string]interface{}{
+			"backup_name": b.BackupName,
+			"backup_time": backupTime,
+			"data_size":   b.DataSize,
+		})
+	}
+
+	return result
+}
+
+// serviceBackupTime parses the time of a backup, returning the zero time when it is invalid
+func serviceBackupTime(backupTime string) time.Time {
+	t, err := time.Parse(time.RFC3339, backupTime)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return t
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAivenServiceBackupsDataSource_basic(t *testing.T) {
+	datasourceName := "data.aiven_service_backups.backups"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenServiceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceBackupsDataSource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceName, "project", os.Getenv("AIVEN_PROJECT_NAME")),
+					resource.TestCheckResourceAttr(datasourceName, "service_name", fmt.Sprintf("test-acc-sr-%s", rName)),
+					resource.TestCheckResourceAttrSet(datasourceName, "backups.0.backup_name"),
+					resource.TestCheckResourceAttrSet(datasourceName, "backups.0.backup_time"),
+					resource.TestCheckResourceAttrSet(datasourceName, "oldest_backup_time"),
+					resource.TestCheckResourceAttrSet(datasourceName, "latest_backup_time"),
+				),
+			},
+		},
+	})
+}
+
+func testAccServiceBackupsDataSource(name string) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_pg" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-4"
+			service_name = "test-acc-sr-%s"
+		}
+
+		data "aiven_service_backups" "backups" {
+			project = aiven_pg.bar.project
+			service_name = aiven_pg.bar.service_name
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name)
+}
+
+func Test_flattenServiceBackups(t *testing.T) {
+	backups := []serviceBackup{
+		{BackupName: "backup-2021-11-21", BackupTime: "2021-11-21T08:00:00.123000Z", DataSize: 2048},
+		{BackupName: "backup-2021-11-20", BackupTime: "2021-11-20T08:00:00Z", DataSize: 1024},
+	}
+
+	want := []map[string]interface{}{
+		{"backup_name": "backup-2021-11-20", "backup_time": "2021-11-20T08:00:00Z", "data_size": 1024},
+		{"backup_name": "backup-2021-11-21", "backup_time": "2021-11-21T08:00:00Z", "data_size": 2048},
+	}
+
+	if got := flattenServiceBackups(backups); !reflect.DeepEqual(got, want) {
+		t.Errorf("flattenServiceBackups() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"net"
+	"sort"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceServiceIPAddresses() *schema.Resource {
+	return &schema.Resource{
+		Description: "The Service IP Addresses data source provides the IP addresses an existing Aiven service uses for incoming and outgoing connections, for example for firewall allowlists.",
+		ReadContext: datasourceServiceIPAddressesRead,
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Project name",
+			},
+			"service_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Service name",
+			},
+			"static_ips_enabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the service uses its static IP addresses, enabled by `static_ips` in the user configuration of the service",
+			},
+			"static_ip_addresses": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Static IP addresses associated with the service",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"static_ip_address_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Identifier of the static IP address",
+						},
+						"ip_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The static IP address",
+						},
+						"state": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "State of the static IP address",
+						},
+					},
+				},
+			},
+			"ingress_ip_addresses": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IP addresses the service accepts connections on. These are the assigned static IP addresses when static IP addresses are enabled, otherwise the addresses the hostnames of the service resolve to, which can change when the service is rebuilt.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"egress_ip_addresses": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IP addresses the service connects to other systems from. These are only known when static IP addresses are enabled, otherwise the list is empty.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func datasourceServiceIPAddressesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+
+	service, err := client.Services.Get(projectName, serviceName)
+	if err != nil {
+		return diag.Errorf("service %s/%s not found: %s", projectName, serviceName, err)
+	}
+
+	ips, err := getStaticIPs(client, projectName)
+	if err != nil {
+		return diag.Errorf("cannot get static IP addresses of project %s: %s", projectName, err)
+	}
+
+	d.SetId(buildResourceID(projectName, serviceName))
+
+	enabled, _ := service.UserConfig["static_ips"].(bool)
+	if err := d.Set("static_ips_enabled", enabled); err != nil {
+		return diag.FromErr(err)
+	}
+
+	var staticIPs []map[string]interface{}
+	var assigned []string
+	for _, ip := range ips {
+		if ip.ServiceName != serviceName {
+			continue
+		}
+
+		staticIPs = append(staticIPs, map[string]interface{}{
+			"static_ip_address_id": ip.StaticIPAddressID,
+			"ip_address":           ip.IPAddress,
+			"state":                ip.State,
+		})
+		if ip.State == "assigned" {
+			assigned = append(assigned, ip.IPAddress)
+		}
+	}
+	sort.Strings(assigned)
+
+	if err := d.Set("static_ip_addresses", staticIPs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	ingress, egress := assigned, assigned
+	if !enabled {
+		egress = []string{}
+		if ingress, err = serviceComponentIPAddresses(ctx, service); err != nil {
+			return diag.Errorf("cannot resolve the addresses of service %s: %s", serviceName, err)
+		}
+	}
+
+	if err := d.Set("ingress_ip_addresses", ingress); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("egress_ip_addresses", egress); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// serviceComponentIPAddresses resolves the public hostnames of the components of a service
+func serviceComponentIPAddresses(ctx context.Context, service *aiven.Service) ([]string, error) {
+	seen := make(map[string]bool)
+	var addresses []string
+
+	for _, c := range service.Components {
+		if c.Route != "dynamic" && c.Route != "public" {
+			continue
+		}
+		if seen[c.Host] {
+			continue
+		}
+		seen[c.Host] = true
+
+		hostAddresses, err := net.DefaultResolver.LookupHost(ctx, c.Host)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, a := range hostAddresses {
+			if !seen[a] {
+				seen[a] = true
+				addresses = append(addresses, a)
+			}
+		}
+	}
+	sort.Strings(addresses)
+
+	return addresses, nil
+}
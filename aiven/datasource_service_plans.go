@@ -0,0 +1,149 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func datasourceServicePlans() *schema.Resource {
+	return &schema.Resource{
+		Description: "The Service Plans data source provides the plans of a service type available in a cloud, with their specification and price.",
+		ReadContext: datasourceServicePlansRead,
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Project name, the plans and prices can depend on the project",
+			},
+			"service_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(availableServiceTypes(), false),
+				Description:  "Service type code, e.g. `pg`",
+			},
+			"cloud_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Cloud name, e.g. `google-europe-west1`",
+			},
+			"service_plans": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Plans of the service type available in the cloud, ordered by price",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"service_plan": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Plan name",
+						},
+						"node_count": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of nodes of the services on the plan",
+						},
+						"node_cpu_count": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of CPUs of each node",
+						},
+						"node_memory_mb": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Memory of each node in MiB",
+						},
+						"disk_space_mb": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Disk space included in the plan in MiB",
+						},
+						"disk_space_cap_mb": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Maximum disk space of the plan including additional disk space in MiB",
+						},
+						"hourly_price_usd": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Hourly price in US dollars",
+						},
+						"monthly_price_usd": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Monthly price in US dollars, estimated for 730 hours",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func datasourceServicePlansRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	projectName := d.Get("project").(string)
+	serviceType := d.Get("service_type").(string)
+	cloud := d.Get("cloud_name").(string)
+
+	plans, err := getServicePlans(m, projectName, serviceType)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(buildResourceID(projectName, serviceType, cloud))
+
+	if err := d.Set("service_plans", flattenServicePlans(plans, cloud)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// flattenServicePlans returns the plans available in a cloud ordered by price and name
+func flattenServicePlans(plans []servicePlan, cloud string) []map[string]interface{} {
+	type pricedPlan struct {
+		plan   servicePlan
+		region servicePlanRegion
+		price  float64
+	}
+
+	var available []pricedPlan
+	for _, p := range plans {
+		region, ok := p.Regions[cloud]
+		if !ok {
+			continue
+		}
+
+		price, _ := strconv.ParseFloat(region.PriceUSD, 64)
+		available = append(available, pricedPlan{plan: p, region: region, price: price})
+	}
+
+	sort.SliceStable(available, func(i, j int) bool {
+		if available[i].price != available[j].price {
+			return available[i].price < available[j].price
+		}
+		return available[i].plan.ServicePlan < available[j].plan.ServicePlan
+	})
+
+	result := make([]map[string]interface{}, 0, len(available))
+	for _, a := range available {
+		hourly, monthly, _ := servicePlanPrices(a.region)
+		result = append(result, map[string]interface{}{
+			"service_plan":      a.plan.ServicePlan,
+			"node_count":        a.plan.NodeCount,
+			"node_cpu_count":    a.region.NodeCPUCount,
+			"node_memory_mb":    a.region.NodeMemoryMB,
+			"disk_space_mb":     a.region.DiskSpaceMB,
+			"disk_space_cap_mb": a.region.DiskSpaceCapMB,
+			"hourly_price_usd":  hourly,
+			"monthly_price_usd": monthly,
+		})
+	}
+
+	return result
+}
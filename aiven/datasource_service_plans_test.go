@@ -0,0 +1,81 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAivenServicePlansDataSource_basic(t *testing.T) {
+	datasourceName := "data.aiven_service_plans.plans"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServicePlansDataSource(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(datasourceName, "service_plans.0.service_plan"),
+					resource.TestCheckResourceAttrSet(datasourceName, "service_plans.0.node_memory_mb"),
+					resource.TestCheckResourceAttrSet(datasourceName, "service_plans.0.monthly_price_usd"),
+				),
+			},
+		},
+	})
+}
+
+func testAccServicePlansDataSource() string {
+	return fmt.Sprintf(`
+		data "aiven_service_plans" "plans" {
+			project = "%s"
+			service_type = "pg"
+			cloud_name = "google-europe-west1"
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"))
+}
+
+func Test_flattenServicePlans(t *testing.T) {
+	plans := []servicePlan{
+		{ServicePlan: "business-4", ServiceType: "pg", NodeCount: 2, Regions: map[string]servicePlanRegion{
+			"google-europe-west1": {DiskSpaceMB: 81920, DiskSpaceCapMB: 409600, NodeCPUCount: 1, NodeMemoryMB: 4096, PriceUSD: "0.2740"},
+		}},
+		{ServicePlan: "startup-4", ServiceType: "pg", NodeCount: 1, Regions: map[string]servicePlanRegion{
+			"google-europe-west1": {DiskSpaceMB: 81920, DiskSpaceCapMB: 409600, NodeCPUCount: 1, NodeMemoryMB: 4096, PriceUSD: "0.1370"},
+			"aws-eu-west-1":       {DiskSpaceMB: 81920, DiskSpaceCapMB: 409600, NodeCPUCount: 1, NodeMemoryMB: 4096, PriceUSD: "0.1370"},
+		}},
+		{ServicePlan: "premium-4", ServiceType: "pg", NodeCount: 3, Regions: map[string]servicePlanRegion{
+			"aws-eu-west-1": {DiskSpaceMB: 81920, DiskSpaceCapMB: 409600, NodeCPUCount: 1, NodeMemoryMB: 4096, PriceUSD: "0.4110"},
+		}},
+	}
+
+	want := []map[string]interface{}{
+		{
+			"service_plan":      "startup-4",
+			"node_count":        1,
+			"node_cpu_count":    1,
+			"node_memory_mb":    4096,
+			"disk_space_mb":     81920,
+			"disk_space_cap_mb": 409600,
+			"hourly_price_usd":  "0.1370",
+			"monthly_price_usd": "100.01",
+		},
+		{
+			"service_plan":      "business-4",
+			"node_count":        2,
+			"node_cpu_count":    1,
+			"node_memory_mb":    4096,
+			"disk_space_mb":     81920,
+			"disk_space_cap_mb": 409600,
+			"hourly_price_usd":  "0.2740",
+			"monthly_price_usd": "200.02",
+		},
+	}
+	if got := flattenServicePlans(plans, "google-europe-west1"); !reflect.DeepEqual(got, want) {
+		t.Errorf("flattenServicePlans() = %v, want %v", got, want)
+	}
+}
@@ -5,22 +5,25 @@ package aiven
 import (
 	"context"
 
-	"github.com/aiven/aiven-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func datasourceServiceUser() *schema.Resource {
+	s := resourceSchemaAsDatasourceSchema(aivenServiceUserSchema,
+		"project", "service_name", "username")
+	// grants are applied over a connection to the service and cannot be read from the API
+	delete(s, "access_control")
+
 	return &schema.Resource{
 		ReadContext: datasourceServiceUserRead,
 		Description: "The Service User data source provides information about the existing Aiven Service User.",
-		Schema: resourceSchemaAsDatasourceSchema(aivenServiceUserSchema,
-			"project", "service_name", "username"),
+		Schema:      s,
 	}
 }
 
 func datasourceServiceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
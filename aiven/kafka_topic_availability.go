@@ -66,6 +66,11 @@ func (w *KafkaTopicAvailabilityWaiter) RefreshFunc() resource.StateRefreshFunc {
 				// Getting topic info can sometimes temporarily fail with 501 and 502. Don't
 				// treat that as fatal error but keep on retrying instead.
 				if aivenError.Status == 501 || aivenError.Status == 502 {
+					// there is no point in waiting for the topics of a powered off service
+					if servicePoweredOff(w.Client, w.Project, w.ServiceName) {
+						return nil, "CONFIGURING", err
+					}
+
 					log.Printf("[DEBUG] Got an error while waiting for a topic '%s' to be ACTIVE: %s.", w.TopicName, err)
 					return nil, "CONFIGURING", nil
 				}
@@ -0,0 +1,304 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// projectCloneOptions selects what is copied from the source project of the clone block
+type projectCloneOptions struct {
+	SourceProject        string
+	BillingGroup         bool
+	VPCs                 bool
+	IntegrationEndpoints bool
+	TeamGrants           bool
+}
+
+// projectCloneResult is what was actually copied to the new project
+type projectCloneResult struct {
+	BillingGroup           string
+	VPCIDs                 []string
+	IntegrationEndpointIDs []string
+	TeamIDs                []string
+}
+
+func projectCloneSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeList,
+		Optional:         true,
+		MaxItems:         1,
+		DiffSuppressFunc: createOnlyDiffSuppressFunc,
+		ConflictsWith:    []string{"copy_from_project", "use_source_project_billing_group"},
+		Description:      "Creates the project as a copy of another project. The billing information and technical contacts are always copied, the other parts are selected by the fields of the block. The copied VPCs and integration endpoints are not managed by Terraform, they can be imported as resources of their own. The peering connections of the VPCs are not copied. The integration endpoints are read one by one including their secrets and created with the same name, type and user configuration, their ids and their computed `endpoint_config` differ from the source project. When copying fails, the parts already copied are removed from the project again. This only has effect when the project is created.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"source_project": {
+					Type:             schema.TypeString,
+					Required:         true,
+					DiffSuppressFunc: createOnlyDiffSuppressFunc,
+					Description:      "Name of the project to copy from, it must be another project.",
+				},
+				"billing_group": {
+					Type:             schema.TypeBool,
+					Optional:         true,
+					Default:          true,
+					DiffSuppressFunc: createOnlyDiffSuppressFunc,
+					Description:      complex("Assign the project to the billing group of the source project.").defaultValue(true).build(),
+				},
+				"vpcs": {
+					Type:             schema.TypeBool,
+					Optional:         true,
+					Default:          false,
+					DiffSuppressFunc: createOnlyDiffSuppressFunc,
+					Description:      complex("Create the VPCs of the source project with the same clouds and network ranges, without their peering connections.").defaultValue(false).build(),
+				},
+				"integration_endpoints": {
+					Type:             schema.TypeBool,
+					Optional:         true,
+					Default:          false,
+					DiffSuppressFunc: createOnlyDiffSuppressFunc,
+					Description:      complex("Create the integration endpoints of the source project with the same configuration, including the secrets of the configuration.").defaultValue(false).build(),
+				},
+				"team_grants": {
+					Type:             schema.TypeBool,
+					Optional:         true,
+					Default:          false,
+					DiffSuppressFunc: createOnlyDiffSuppressFunc,
+					Description:      complex("Grant the account teams of the source project the same access to the project. Both projects must belong to the same account.").defaultValue(false).build(),
+				},
+			},
+		},
+	}
+}
+
+func projectClonedSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "What was copied from the source project of the `clone` block when the project was created.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"billing_group": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Billing group the project was assigned to.",
+				},
+				"vpc_ids": {
+					Type:        schema.TypeList,
+					Computed:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Ids of the VPCs created in the project.",
+				},
+				"integration_endpoint_ids": {
+					Type:        schema.TypeList,
+					Computed:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Ids of the integration endpoints created in the project.",
+				},
+				"team_ids": {
+					Type:        schema.TypeList,
+					Computed:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Ids of the account teams granted access to the project.",
+				},
+			},
+		},
+	}
+}
+
+// getProjectCloneOptions returns the options of the clone block, nil when it is not set
+func getProjectCloneOptions(d *schema.ResourceData) *projectCloneOptions {
+	l := d.Get("clone").([]interface{})
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+	c := l[0].(map[string]interface{})
+
+	return &projectCloneOptions{
+		SourceProject:        c["source_project"].(string),
+		BillingGroup:         c["billing_group"].(bool),
+		VPCs:                 c["vpcs"].(bool),
+		IntegrationEndpoints: c["integration_endpoints"].(bool),
+		TeamGrants:           c["team_grants"].(bool),
+	}
+}
+
+// cloneProject copies the VPCs, integration endpoints and team grants selected by the options
+// from the source project to the target project, the billing group is assigned by the caller.
+// When copying fails everything already copied is removed from the target project again.
+func cloneProject(
+	client *aiven.Client, source, target *aiven.Project, opts projectCloneOptions) (_ *projectCloneResult, err error) {
+	result := &projectCloneResult{
+		VPCIDs:                 []string{},
+		IntegrationEndpointIDs: []string{},
+		TeamIDs:                []string{},
+	}
+
+	defer func() {
+		if err != nil {
+			if rollbackErr := rollbackProjectClone(client, source, target, result); rollbackErr != nil {
+				err = fmt.Errorf("%w, removing what was already copied failed, it must be removed by hand: %s", err, rollbackErr)
+			}
+		}
+	}()
+
+	if opts.VPCs {
+		vpcs, err := client.VPCs.List(source.Name)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get the VPCs of project %s: %w", source.Name, err)
+		}
+
+		for _, vpc := range cloneableVPCs(vpcs) {
+			log.Printf("[DEBUG] Copying VPC `%s` of project `%s`", vpc.ProjectVPCID, source.Name)
+			created, err := client.VPCs.Create(target.Name, aiven.CreateVPCRequest{
+				CloudName:          vpc.CloudName,
+				NetworkCIDR:        vpc.NetworkCIDR,
+				PeeringConnections: []*aiven.VPCPeeringConnection{},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("cannot copy VPC %s/%s: %w", vpc.CloudName, vpc.NetworkCIDR, err)
+			}
+			result.VPCIDs = append(result.VPCIDs, created.ProjectVPCID)
+		}
+	}
+
+	if opts.IntegrationEndpoints {
+		endpoints, err := client.ServiceIntegrationEndpoints.List(source.Name)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get the integration endpoints of project %s: %w", source.Name, err)
+		}
+
+		for _, listed := range endpoints {
+			// the listed user configuration has its secrets masked
+			e, err := getIntegrationEndpointWithSecrets(client, source.Name, listed.EndpointID)
+			if err != nil {
+				return nil, fmt.Errorf("cannot get integration endpoint %s of project %s: %w", listed.EndpointName, source.Name, err)
+			}
+
+			log.Printf("[DEBUG] Copying integration endpoint `%s` of project `%s`", e.EndpointName, source.Name)
+			created, err := client.ServiceIntegrationEndpoints.Create(target.Name, aiven.CreateServiceIntegrationEndpointRequest{
+				EndpointName: e.EndpointName,
+				EndpointType: e.EndpointType,
+				UserConfig:   e.UserConfig,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("cannot copy integration endpoint %s: %w", e.EndpointName, err)
+			}
+			result.IntegrationEndpointIDs = append(result.IntegrationEndpointIDs, created.EndpointID)
+		}
+	}
+
+	if opts.TeamGrants {
+		if source.AccountId == "" || source.AccountId != target.AccountId {
+			return nil, fmt.Errorf("cannot copy the team grants of project %s, the projects do not belong to the same account", source.Name)
+		}
+
+		teams, err := client.AccountTeams.List(source.AccountId)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get the teams of account %s: %w", source.AccountId, err)
+		}
+
+		for _, t := range teams.Teams {
+			rp, err := client.AccountTeamProjects.List(source.AccountId, t.Id)
+			if err != nil {
+				return nil, fmt.Errorf("cannot get the projects of account team %s: %w", t.Name, err)
+			}
+
+			for _, p := range rp.Projects {
+				if p.ProjectName != source.Name {
+					continue
+				}
+
+				log.Printf("[DEBUG] Granting account team `%s` %s access to project `%s`", t.Name, p.TeamType, target.Name)
+				err := client.AccountTeamProjects.Create(source.AccountId, t.Id, aiven.AccountTeamProject{
+					ProjectName: target.Name,
+					TeamType:    p.TeamType,
+				})
+				if err != nil {
+					return nil, fmt.Errorf("cannot grant account team %s access to project %s: %w", t.Name, target.Name, err)
+				}
+				result.TeamIDs = append(result.TeamIDs, t.Id)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// getIntegrationEndpointWithSecrets returns an integration endpoint with the secrets of its user
+// configuration, aiven-go-client only lists the endpoints with the secrets masked
+func getIntegrationEndpointWithSecrets(client *aiven.Client, projectName, endpointID string) (*aiven.ServiceIntegrationEndpoint, error) {
+	var r struct {
+		ServiceIntegrationEndpoint aiven.ServiceIntegrationEndpoint `json:"service_integration_endpoint"`
+	}
+
+	path := clientRequestPath("project", projectName, "integration_endpoint", endpointID) + "?include_secrets=true"
+	if err := clientRequest(client, http.MethodGet, path, nil, &r); err != nil {
+		return nil, err
+	}
+
+	return &r.ServiceIntegrationEndpoint, nil
+}
+
+// rollbackProjectClone removes the VPCs, integration endpoints and team grants copied to the
+// target project by a failed clone
+func rollbackProjectClone(client *aiven.Client, source, target *aiven.Project, result *projectCloneResult) error {
+	var failed []string
+
+	for _, id := range result.TeamIDs {
+		log.Printf("[DEBUG] Removing the access of account team `%s` to project `%s`", id, target.Name)
+		if err := client.AccountTeamProjects.Delete(source.AccountId, id, target.Name); err != nil && !aiven.IsNotFound(err) {
+			failed = append(failed, fmt.Sprintf("team grant %s: %s", id, err))
+		}
+	}
+
+	for _, id := range result.IntegrationEndpointIDs {
+		log.Printf("[DEBUG] Removing integration endpoint `%s` of project `%s`", id, target.Name)
+		if err := client.ServiceIntegrationEndpoints.Delete(target.Name, id); err != nil && !aiven.IsNotFound(err) {
+			failed = append(failed, fmt.Sprintf("integration endpoint %s: %s", id, err))
+		}
+	}
+
+	for _, id := range result.VPCIDs {
+		log.Printf("[DEBUG] Removing VPC `%s` of project `%s`", id, target.Name)
+		if err := client.VPCs.Delete(target.Name, id); err != nil && !aiven.IsNotFound(err) {
+			failed = append(failed, fmt.Sprintf("VPC %s: %s", id, err))
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("%s", strings.Join(failed, "; "))
+	}
+
+	return nil
+}
+
+// cloneableVPCs returns the VPCs of a project that are neither deleted nor being deleted
+func cloneableVPCs(vpcs []*aiven.VPC) []*aiven.VPC {
+	var r []*aiven.VPC
+	for _, vpc := range vpcs {
+		if vpc.State == "DELETING" || vpc.State == "DELETED" {
+			continue
+		}
+		r = append(r, vpc)
+	}
+
+	return r
+}
+
+func flattenProjectCloneResult(r *projectCloneResult) []map[string]interface{} {
+	return []map[string]interface{}{
+		{
+			"billing_group":            r.BillingGroup,
+			"vpc_ids":                  r.VPCIDs,
+			"integration_endpoint_ids": r.IntegrationEndpointIDs,
+			"team_ids":                 r.TeamIDs,
+		},
+	}
+}
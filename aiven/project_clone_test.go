@@ -0,0 +1,72 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func Test_getProjectCloneOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  map[string]interface{}
+		want *projectCloneOptions
+	}{
+		{
+			"not set",
+			map[string]interface{}{"project": "foo"},
+			nil,
+		},
+		{
+			"defaults",
+			map[string]interface{}{
+				"project": "foo",
+				"clone":   []interface{}{map[string]interface{}{"source_project": "bar"}},
+			},
+			&projectCloneOptions{SourceProject: "bar", BillingGroup: true},
+		},
+		{
+			"selected",
+			map[string]interface{}{
+				"project": "foo",
+				"clone": []interface{}{map[string]interface{}{
+					"source_project":        "bar",
+					"billing_group":         false,
+					"vpcs":                  true,
+					"integration_endpoints": true,
+					"team_grants":           true,
+				}},
+			},
+			&projectCloneOptions{SourceProject: "bar", VPCs: true, IntegrationEndpoints: true, TeamGrants: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, aivenProjectSchema, tt.raw)
+			if got := getProjectCloneOptions(d); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getProjectCloneOptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_cloneableVPCs(t *testing.T) {
+	vpcs := []*aiven.VPC{
+		{ProjectVPCID: "1", State: "ACTIVE"},
+		{ProjectVPCID: "2", State: "DELETING"},
+		{ProjectVPCID: "3", State: "APPROVED"},
+		{ProjectVPCID: "4", State: "DELETED"},
+	}
+
+	var got []string
+	for _, vpc := range cloneableVPCs(vpcs) {
+		got = append(got, vpc.ProjectVPCID)
+	}
+
+	if want := []string{"1", "3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("cloneableVPCs() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// projectTeamGrant is the access an account team has to a project
+type projectTeamGrant struct {
+	TeamId   string
+	TeamName string
+	TeamType string
+}
+
+func projectLostTeamGrantsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Account team grants removed from the project by the last move to another account. The teams of an account lose their access to the projects moved out of the account.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"team_id": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Account team id",
+				},
+				"team_name": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Account team name",
+				},
+				"team_type": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Access the team had to the project",
+				},
+			},
+		},
+	}
+}
+
+// resourceProjectCustomizeDiff validates the source of a cloned project, and the move of a project
+// to another account or billing group and plans the team grants lost by the move
+func resourceProjectCustomizeDiff(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" {
+		sourceProject := d.Get("clone.0.source_project").(string)
+		if sourceProject != "" && sourceProject == d.Get("project").(string) {
+			return fmt.Errorf("project %s cannot be cloned from itself, clone.source_project must be another project", sourceProject)
+		}
+		return nil
+	}
+
+	oldAccount, newAccount := d.GetChange("account_id")
+	oldBillingGroup, newBillingGroup := d.GetChange("billing_group")
+	accountMoved := newAccount.(string) != "" && oldAccount.(string) != newAccount.(string)
+	billingGroupMoved := newBillingGroup.(string) != "" && oldBillingGroup.(string) != newBillingGroup.(string)
+	if !d.NewValueKnown("account_id") || !d.NewValueKnown("billing_group") {
+		// the target is created in the same apply and is checked by the API
+		if d.HasChange("account_id") {
+			return d.SetNewComputed("lost_team_grants")
+		}
+		return nil
+	}
+	if !accountMoved && !billingGroupMoved {
+		return nil
+	}
+
+	client := m.(*providerMeta).client
+
+	if accountMoved {
+		if err := checkAccountOwner(client, newAccount.(string)); err != nil {
+			return fmt.Errorf("cannot move project %s to account %s: %w", d.Id(), newAccount, err)
+		}
+
+		grants := []projectTeamGrant{}
+		if oldAccount.(string) != "" {
+			if err := checkAccountOwner(client, oldAccount.(string)); err != nil {
+				return fmt.Errorf("cannot move project %s out of account %s: %w", d.Id(), oldAccount, err)
+			}
+
+			var err error
+			if grants, err = getProjectTeamGrants(client, oldAccount.(string), d.Id()); err != nil {
+				return err
+			}
+		}
+
+		if err := d.SetNew("lost_team_grants", flattenProjectTeamGrants(grants)); err != nil {
+			return err
+		}
+	}
+
+	billingGroupId := newBillingGroup.(string)
+	if billingGroupId == "" {
+		return nil
+	}
+
+	bg, err := client.BillingGroup.Get(billingGroupId)
+	if err != nil {
+		return fmt.Errorf("cannot get billing group %s: %w", billingGroupId, err)
+	}
+
+	if bgAccount := stringValue(bg.AccountId); bgAccount != "" && bgAccount != newAccount.(string) {
+		return fmt.Errorf("billing group %s belongs to account %s, project %s can only be assigned to the billing groups of its account", billingGroupId, bgAccount, d.Id())
+	}
+
+	if billingGroupMoved {
+		currency, _ := d.GetChange("billing_currency")
+		balance, _ := d.GetChange("estimated_balance")
+		return projectMoveCurrencyError(d.Id(), currency.(string), balance.(string), billingGroupId, stringValue(bg.BillingCurrency))
+	}
+
+	return nil
+}
+
+// projectMoveCurrencyError returns an error when a project with an unbilled balance would change its
+// billing currency by moving to another billing group
+func projectMoveCurrencyError(projectName, currency, balance, billingGroupId, billingGroupCurrency string) error {
+	if currency == "" || billingGroupCurrency == "" || currency == billingGroupCurrency {
+		return nil
+	}
+
+	if b, err := strconv.ParseFloat(balance, 64); err != nil || b == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("project %s has an unbilled balance of %s %s, it cannot be moved to billing group %s in %s before the balance is invoiced",
+		projectName, balance, currency, billingGroupId, billingGroupCurrency)
+}
+
+// checkAccountOwner checks that the user of the provider credentials is a member of the owners team
+// of an account, only the account owners can move projects in and out of an account
+func checkAccountOwner(client *aiven.Client, accountId string) error {
+	r, err := client.Accounts.Get(accountId)
+	if err != nil {
+		return fmt.Errorf("cannot get account %s: %w", accountId, err)
+	}
+
+	var me struct {
+		User struct {
+			Email string `json:"user"`
+		} `json:"user"`
+	}
+	if err := clientRequest(client, http.MethodGet, clientRequestPath("me"), nil, &me); err != nil {
+		return fmt.Errorf("cannot get the user of the provider credentials: %w", err)
+	}
+
+	members, err := client.AccountTeamMembers.List(accountId, r.Account.OwnerTeamId)
+	if err != nil {
+		return fmt.Errorf("cannot get the owners of account %s: %w", accountId, err)
+	}
+
+	for _, member := range members.Members {
+		if containsEmail([]string{member.UserEmail}, me.User.Email) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("user %s is not an owner of account %s", me.User.Email, accountId)
+}
+
+// getProjectTeamGrants returns the teams of an account that have access to a project
+func getProjectTeamGrants(client *aiven.Client, accountId, projectName string) ([]projectTeamGrant, error) {
+	r, err := client.AccountTeams.List(accountId)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get the teams of account %s: %w", accountId, err)
+	}
+
+	grants := []projectTeamGrant{}
+	for _, t := range r.Teams {
+		rp, err := client.AccountTeamProjects.List(accountId, t.Id)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get the projects of account team %s: %w", t.Name, err)
+		}
+
+		for _, p := range rp.Projects {
+			if p.ProjectName == projectName {
+				grants = append(grants, projectTeamGrant{TeamId: t.Id, TeamName: t.Name, TeamType: p.TeamType})
+			}
+		}
+	}
+
+	return grants, nil
+}
+
+func flattenProjectTeamGrants(grants []projectTeamGrant) []map[string]interface{} {
+	r := make([]map[string]interface{}, 0, len(grants))
+	for _, g := range grants {
+		r = append(r, map[string]interface{}{
+			"team_id":   g.TeamId,
+			"team_name": g.TeamName,
+			"team_type": g.TeamType,
+		})
+	}
+
+	return r
+}
@@ -0,0 +1,28 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import "testing"
+
+func Test_projectMoveCurrencyError(t *testing.T) {
+	tests := []struct {
+		name                 string
+		currency             string
+		balance              string
+		billingGroupCurrency string
+		wantErr              bool
+	}{
+		{"same currency", "EUR", "12.50", "EUR", false},
+		{"no balance", "EUR", "0.00", "USD", false},
+		{"unknown balance", "EUR", "", "USD", false},
+		{"unknown currency", "", "12.50", "USD", false},
+		{"unbilled balance", "EUR", "12.50", "USD", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := projectMoveCurrencyError("foo", tt.currency, tt.balance, "bar", tt.billingGroupCurrency)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("projectMoveCurrencyError() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
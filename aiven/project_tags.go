@@ -0,0 +1,97 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func projectTagSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Description: "Tags of the project, used for example to allocate the costs of the project.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"key": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringMatch(serviceTagKeyRegexp, "must start with a letter and contain only letters, numbers and the characters _.:-"),
+					Description:  complex("Project tag key.").maxLen(64).build(),
+				},
+				"value": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringLenBetween(0, 64),
+					Description:  complex("Project tag value.").maxLen(64).build(),
+				},
+			},
+		},
+	}
+}
+
+// resourceProjectTagsCustomizeDiff rejects project tags that set the same key twice or that the
+// API does not accept already during plan, the tags follow the rules of the service tags
+func resourceProjectTagsCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("tag") {
+		return nil
+	}
+
+	tags := d.Get("tag").(*schema.Set)
+	if err := validateServiceTagKeys(tags); err != nil {
+		return err
+	}
+
+	return validateServiceTags(expandProjectTags(tags))
+}
+
+// getProjectTags returns the tags of a project, the tags are not covered by aiven-go-client
+func getProjectTags(client *aiven.Client, projectName string) (map[string]string, error) {
+	var r struct {
+		Tags map[string]string `json:"tags"`
+	}
+
+	if err := clientRequest(client, http.MethodGet, clientRequestPath("project", projectName, "tags"), nil, &r); err != nil {
+		return nil, fmt.Errorf("cannot get tags of project %s: %s", projectName, err)
+	}
+
+	return r.Tags, nil
+}
+
+// setProjectTags replaces the tags of a project
+func setProjectTags(client *aiven.Client, projectName string, tags *schema.Set) error {
+	path := clientRequestPath("project", projectName, "tags")
+	req := map[string]interface{}{"tags": expandProjectTags(tags)}
+	if err := clientRequest(client, http.MethodPut, path, req, nil); err != nil {
+		return fmt.Errorf("cannot set tags of project %s: %s", projectName, err)
+	}
+
+	return nil
+}
+
+func expandProjectTags(tags *schema.Set) map[string]string {
+	result := make(map[string]string, tags.Len())
+	for _, tag := range tags.List() {
+		m := tag.(map[string]interface{})
+		result[m["key"].(string)] = m["value"].(string)
+	}
+
+	return result
+}
+
+func flattenProjectTags(tags map[string]string) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(tags))
+	for k, v := range tags {
+		result = append(result, map[string]interface{}{
+			"key":   k,
+			"value": v,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func Test_resourceProjectTagsCustomizeDiff(t *testing.T) {
+	tag := func(key, value string) map[string]interface{} {
+		return map[string]interface{}{"key": key, "value": value}
+	}
+
+	tests := []struct {
+		name    string
+		tags    []interface{}
+		wantErr bool
+	}{
+		{"tags", []interface{}{tag("team", "data"), tag("environment", "test")}, false},
+		{"no-tags", []interface{}{}, false},
+		{"duplicate-key", []interface{}{tag("team", "data"), tag("team", "platform")}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"project": "test-project",
+				"tag":     tt.tags,
+			})
+
+			_, err := resourceProject().Diff(context.Background(), nil, config, nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Diff() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/aiven/aiven-go-client"
 	"github.com/aiven/terraform-provider-aiven/aiven/templates"
 	"github.com/aiven/terraform-provider-aiven/pkg/cache"
+	"github.com/aiven/terraform-provider-aiven/pkg/ipfilter"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -29,6 +30,23 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("AIVEN_TOKEN", nil),
 				Description: "Aiven Authentication Token",
 			},
+			"default_tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Tags added to all services managed by the provider",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"tags": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Description: "Tags added to all services, tags set on a service override the default tags with the same key",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"policy": servicePolicySchema(),
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
@@ -41,6 +59,9 @@ func Provider() *schema.Provider {
 			"aiven_kafka_schema_configuration":     datasourceKafkaSchemaConfiguration(),
 			"aiven_project":                        datasourceProject(),
 			"aiven_project_user":                   datasourceProjectUser(),
+			"aiven_project_users":                  datasourceProjectUsers(),
+			"aiven_project_events":                 datasourceProjectEvents(),
+			"aiven_billing_group_invoices":         datasourceBillingGroupInvoices(),
 			"aiven_project_vpc":                    datasourceProjectVPC(),
 			"aiven_vpc_peering_connection":         datasourceVPCPeeringConnection(),
 			"aiven_service_integration":            datasourceServiceIntegration(),
@@ -48,7 +69,9 @@ func Provider() *schema.Provider {
 			"aiven_service_user":                   datasourceServiceUser(),
 			"aiven_account":                        datasourceAccount(),
 			"aiven_account_team":                   datasourceAccountTeam(),
+			"aiven_account_teams":                  datasourceAccountTeams(),
 			"aiven_account_team_project":           datasourceAccountTeamProject(),
+			"aiven_account_team_projects":          datasourceAccountTeamProjects(),
 			"aiven_account_team_member":            datasourceAccountTeamMember(),
 			"aiven_mirrormaker_replication_flow":   datasourceMirrorMakerReplicationFlowTopic(),
 			"aiven_account_authentication":         datasourceAccountAuthentication(),
@@ -66,6 +89,9 @@ func Provider() *schema.Provider {
 			"aiven_redis":                          datasourceRedis(),
 			"aiven_transit_gateway_vpc_attachment": datasourceTransitGatewayVPCAttachment(),
 			"aiven_service_component":              datasourceServiceComponent(),
+			"aiven_service_backups":                datasourceServiceBackups(),
+			"aiven_service_ip_addresses":           datasourceServiceIPAddresses(),
+			"aiven_service_plans":                  datasourceServicePlans(),
 			"aiven_m3db":                           datasourceM3DB(),
 			"aiven_m3aggregator":                   datasourceM3Aggregator(),
 			"aiven_aws_privatelink":                datasourceAWSPrivatelink(),
@@ -95,11 +121,19 @@ func Provider() *schema.Provider {
 			"aiven_service_integration":            resourceServiceIntegration(),
 			"aiven_service_integration_endpoint":   resourceServiceIntegrationEndpoint(),
 			"aiven_service_user":                   resourceServiceUser(),
+			"aiven_service_read_replica":           resourceServiceReadReplica(),
+			"aiven_service_fork":                   resourceServiceFork(),
+			"aiven_service_restore":                resourceServiceRestore(),
+			"aiven_static_ip":                      resourceStaticIP(),
+			"aiven_static_ip_association":          resourceStaticIPAssociation(),
+			"aiven_user_token":                     resourceUserToken(),
 			"aiven_account":                        resourceAccount(),
 			"aiven_account_team":                   resourceAccountTeam(),
 			"aiven_account_team_project":           resourceAccountTeamProject(),
 			"aiven_account_team_member":            resourceAccountTeamMember(),
+			"aiven_account_team_members":           resourceAccountTeamMembers(),
 			"aiven_mirrormaker_replication_flow":   resourceMirrorMakerReplicationFlow(),
+			"aiven_kafka_replication_topology":     resourceKafkaReplicationTopology(),
 			"aiven_account_authentication":         resourceAccountAuthentication(),
 			"aiven_kafka":                          resourceKafka(),
 			"aiven_kafka_connect":                  resourceKafkaConnect(),
@@ -117,6 +151,7 @@ func Provider() *schema.Provider {
 			"aiven_m3db":                           resourceM3DB(),
 			"aiven_m3aggregator":                   resourceM3Aggregator(),
 			"aiven_billing_group":                  resourceBillingGroup(),
+			"aiven_project_credit":                 resourceProjectCredit(),
 			"aiven_aws_privatelink":                resourceAWSPrivatelink(),
 			"aiven_opensearch":                     resourceOpensearch(),
 			"aiven_opensearch_acl_config":          resourceOpensearchACLConfig(),
@@ -150,7 +185,12 @@ func Provider() *schema.Provider {
 			return nil, diag.FromErr(err)
 		}
 
-		return client, nil
+		meta, err := newProviderMeta(client, d)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		return meta, nil
 	}
 
 	return p
@@ -369,21 +409,47 @@ func emptyObjectNoChangeDiffSuppressFunc(k, _, new string, d *schema.ResourceDat
 // Terraform does not allow default values for arrays but the IP filter user config value
 // has default. We don't want to force users to always define explicit value just because
 // of the Terraform restriction so suppress the change from default to empty (which would
-// be nonsensical operation anyway)
-func ipFilterArrayDiffSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
-	if old == "1" && new == "0" && strings.HasSuffix(k, ".ip_filter.#") {
-		if list, ok := d.Get(strings.TrimSuffix(k, ".#")).([]interface{}); ok {
-			if len(list) == 1 {
-				return list[0] == "0.0.0.0/0"
-			}
+// be nonsensical operation anyway). The IP filter is a set of networks, so neither the order,
+// duplicates nor the notation of the networks are changes.
+func ipFilterArrayDiffSuppressFunc(k, _, _ string, d *schema.ResourceData) bool {
+	return ipFilterUnchanged(k, d)
+}
+
+func ipFilterValueDiffSuppressFunc(k, _, _ string, d *schema.ResourceData) bool {
+	return ipFilterUnchanged(k, d)
+}
+
+// ipFilterUnchanged reports whether the IP filter of the user config containing the key k is
+// the same before and after the change, including the entries with descriptions
+func ipFilterUnchanged(k string, d *schema.ResourceData) bool {
+	i := strings.LastIndex(k, ".ip_filter")
+	if i < 0 {
+		return false
+	}
+
+	filter := func(networks, objects interface{}) []interface{} {
+		n, _ := networks.([]interface{})
+		var o []interface{}
+		if s, ok := objects.(*schema.Set); ok {
+			o = s.List()
 		}
+		return ipfilter.Merge(n, o)
 	}
 
-	return false
+	oldNetworks, newNetworks := d.GetChange(k[:i] + ".ip_filter")
+	oldObjects, newObjects := d.GetChange(k[:i] + ".ip_filter_object")
+	o, n := filter(oldNetworks, oldObjects), filter(newNetworks, newObjects)
+
+	if len(n) == 0 && ipfilter.IsDefault(o) {
+		return true
+	}
+
+	return ipfilter.Equal(o, n)
 }
 
-func ipFilterValueDiffSuppressFunc(k, old, new string, _ *schema.ResourceData) bool {
-	return old == "0.0.0.0/0" && new == "" && strings.HasSuffix(k, ".ip_filter.0")
+// ipFilterNetworkDiffSuppressFunc suppresses the changes of the notation of an IP filter network
+func ipFilterNetworkDiffSuppressFunc(_, old, new string, _ *schema.ResourceData) bool {
+	return ipfilter.Canonical(old) == ipfilter.Canonical(new)
 }
 
 // validateDurationString is a ValidateFunc that ensures a string parses
@@ -414,6 +480,27 @@ func resourceReadHandleNotFound(err error, d *schema.ResourceData) error {
 	return err
 }
 
+// resourceReadHandleServicePoweredOff keeps the current state of a resource belonging to a
+// service when it cannot be read because the service is powered off, other errors are handled
+// like in resourceReadHandleNotFound
+func resourceReadHandleServicePoweredOff(err error, d *schema.ResourceData, client *aiven.Client, projectName, serviceName string) error {
+	if err != nil && servicePoweredOff(client, projectName, serviceName) {
+		log.Printf("[WARN] service %s/%s is powered off, keeping the current state of %s", projectName, serviceName, d.Id())
+		return nil
+	}
+
+	return resourceReadHandleNotFound(err, d)
+}
+
+func servicePoweredOff(client *aiven.Client, projectName, serviceName string) bool {
+	service, err := client.Services.Get(projectName, serviceName)
+	if err != nil {
+		return false
+	}
+
+	return service.State == aivenPoweroffState
+}
+
 // generateServiceUserConfiguration generate service user_config
 func generateServiceUserConfiguration(t string) *schema.Schema {
 	s := GenerateTerraformUserConfigSchema(
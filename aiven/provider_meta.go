@@ -0,0 +1,42 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// providerMeta is the meta given to resources and data sources, it holds the API client, the
+// provider level settings used by resources and the caches shared by them
+type providerMeta struct {
+	client      *aiven.Client
+	defaultTags map[string]string
+	policy      *servicePolicy
+	plans       *servicePlanCache
+}
+
+// newProviderMeta reads the provider level settings next to a configured client
+func newProviderMeta(client *aiven.Client, d *schema.ResourceData) (*providerMeta, error) {
+	meta := &providerMeta{
+		client:      client,
+		defaultTags: make(map[string]string),
+		plans:       newServicePlanCache(),
+	}
+
+	if v, ok := d.GetOk("default_tags.0.tags"); ok {
+		for k, t := range v.(map[string]interface{}) {
+			meta.defaultTags[k] = t.(string)
+		}
+	}
+	if err := validateServiceTags(meta.defaultTags); err != nil {
+		return nil, err
+	}
+
+	policy, err := readServicePolicy(d)
+	if err != nil {
+		return nil, err
+	}
+	meta.policy = policy
+
+	return meta, nil
+}
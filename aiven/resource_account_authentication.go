@@ -4,7 +4,14 @@ package aiven
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
 	"fmt"
+	"net/http"
+	"strings"
+	"time"
 
 	"github.com/aiven/aiven-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -12,6 +19,59 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// accountAuthenticationMethod is an account authentication method, aiven-go-client covers only
+// the basic SAML settings
+type accountAuthenticationMethod struct {
+	AccountID              string                                 `json:"account_id,omitempty"`
+	Enabled                bool                                   `json:"authentication_method_enabled"`
+	ID                     string                                 `json:"authentication_method_id,omitempty"`
+	Name                   string                                 `json:"authentication_method_name"`
+	Type                   string                                 `json:"authentication_method_type,omitempty"`
+	AutoJoinTeamID         *string                                `json:"auto_join_team_id"`
+	State                  string                                 `json:"state,omitempty"`
+	SAMLCertificate        string                                 `json:"saml_certificate,omitempty"`
+	SAMLIdpURL             string                                 `json:"saml_idp_url,omitempty"`
+	SAMLEntityID           string                                 `json:"saml_entity_id,omitempty"`
+	SAMLIdpLoginAllowed    bool                                   `json:"saml_idp_login_allowed"`
+	SAMLSignatureAlgorithm string                                 `json:"saml_signature_algorithm,omitempty"`
+	SAMLDigestAlgorithm    string                                 `json:"saml_digest_algorithm,omitempty"`
+	SAMLVariant            string                                 `json:"saml_variant,omitempty"`
+	SAMLFieldMapping       *accountAuthenticationSAMLFieldMapping `json:"saml_field_mapping,omitempty"`
+	SAMLAcsURL             string                                 `json:"saml_acs_url,omitempty"`
+	SAMLMetadataURL        string                                 `json:"saml_metadata_url,omitempty"`
+	SAMLSpCertificate      string                                 `json:"saml_sp_certificate,omitempty"`
+	CreateTime             *time.Time                             `json:"create_time,omitempty"`
+	UpdateTime             *time.Time                             `json:"update_time,omitempty"`
+}
+
+// accountAuthenticationSAMLFieldMapping maps the SAML assertion attributes to the user fields
+type accountAuthenticationSAMLFieldMapping struct {
+	Email     string `json:"email,omitempty"`
+	FirstName string `json:"first_name,omitempty"`
+	LastName  string `json:"last_name,omitempty"`
+	RealName  string `json:"real_name,omitempty"`
+	Identity  string `json:"identity,omitempty"`
+	Groups    string `json:"groups,omitempty"`
+}
+
+// samlIdpMetadata is the part of the SAML metadata document of an identity provider the
+// authentication method is configured from
+type samlIdpMetadata struct {
+	EntityID         string `xml:"entityID,attr"`
+	IDPSSODescriptor struct {
+		KeyDescriptors []struct {
+			Use         string `xml:"use,attr"`
+			Certificate string `xml:"KeyInfo>X509Data>X509Certificate"`
+		} `xml:"KeyDescriptor"`
+		SingleSignOnServices []struct {
+			Binding  string `xml:"Binding,attr"`
+			Location string `xml:"Location,attr"`
+		} `xml:"SingleSignOnService"`
+	} `xml:"IDPSSODescriptor"`
+}
+
+var samlIdpMetadataFields = []string{"saml_certificate", "saml_idp_url", "saml_entity_id"}
+
 var aivenAccountAuthenticationSchema = map[string]*schema.Schema{
 	"account_id": {
 		Type:        schema.TypeString,
@@ -36,19 +96,103 @@ var aivenAccountAuthenticationSchema = map[string]*schema.Schema{
 		Description: complex("Status of account authentication method.").defaultValue(false).build(),
 	},
 	"saml_certificate": {
-		Type:        schema.TypeString,
-		Optional:    true,
-		Description: "SAML Certificate",
+		Type:          schema.TypeString,
+		Optional:      true,
+		Computed:      true,
+		ConflictsWith: []string{"saml_idp_metadata_xml"},
+		Description:   "SAML Certificate",
 	},
 	"saml_idp_url": {
-		Type:        schema.TypeString,
-		Optional:    true,
-		Description: "SAML Idp URL",
+		Type:          schema.TypeString,
+		Optional:      true,
+		Computed:      true,
+		ConflictsWith: []string{"saml_idp_metadata_xml"},
+		Description:   "SAML Idp URL",
 	},
 	"saml_entity_id": {
+		Type:          schema.TypeString,
+		Optional:      true,
+		Computed:      true,
+		ConflictsWith: []string{"saml_idp_metadata_xml"},
+		Description:   "SAML Entity id",
+	},
+	"saml_idp_metadata_xml": {
+		Type:          schema.TypeString,
+		Optional:      true,
+		ConflictsWith: samlIdpMetadataFields,
+		Description:   "SAML metadata XML document of the identity provider. The `saml_certificate`, `saml_idp_url` and `saml_entity_id` are read from the document instead of being set explicitly.",
+	},
+	"saml_idp_login_allowed": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: complex("Allow users to log in from the identity provider dashboard (IdP initiated login).").defaultValue(false).build(),
+	},
+	"saml_signature_algorithm": {
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validation.StringInSlice([]string{"rsa-sha1", "dsa-sha1", "rsa-sha256", "rsa-sha384", "rsa-sha512"}, false),
+		Description:  complex("SAML signature algorithm.").possibleValues("rsa-sha1", "dsa-sha1", "rsa-sha256", "rsa-sha384", "rsa-sha512").build(),
+	},
+	"saml_digest_algorithm": {
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validation.StringInSlice([]string{"sha1", "sha256", "sha384", "sha512"}, false),
+		Description:  complex("SAML digest algorithm.").possibleValues("sha1", "sha256", "sha384", "sha512").build(),
+	},
+	"saml_variant": {
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validation.StringInSlice([]string{"adfs"}, false),
+		Description:  complex("SAML server variant, required for identity providers that deviate from the standard.").possibleValues("adfs").build(),
+	},
+	"saml_field_mapping": {
+		Type:        schema.TypeList,
+		Optional:    true,
+		Computed:    true,
+		MaxItems:    1,
+		Description: "Mapping of the SAML assertion attributes to the fields of the users",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"email": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Attribute of the email address of the user",
+				},
+				"first_name": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Attribute of the first name of the user",
+				},
+				"last_name": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Attribute of the last name of the user",
+				},
+				"real_name": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Attribute of the full name of the user",
+				},
+				"identity": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Attribute of the identity of the user",
+				},
+				"groups": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Attribute of the groups of the user",
+				},
+			},
+		},
+	},
+	"auto_join_team_id": {
 		Type:        schema.TypeString,
 		Optional:    true,
-		Description: "SAML Entity id",
+		Description: complex("Team the users logging in with the authentication method join automatically.").referenced().build(),
 	},
 	"saml_acs_url": {
 		Type:        schema.TypeString,
@@ -60,6 +204,16 @@ var aivenAccountAuthenticationSchema = map[string]*schema.Schema{
 		Computed:    true,
 		Description: "SAML Metadata URL",
 	},
+	"saml_sp_certificate": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "SAML certificate of the service provider, used by the identity provider to verify the signed requests",
+	},
+	"state": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "State of the authentication method",
+	},
 	"authentication_id": {
 		Type:        schema.TypeString,
 		Computed:    true,
@@ -79,7 +233,7 @@ var aivenAccountAuthenticationSchema = map[string]*schema.Schema{
 
 func resourceAccountAuthentication() *schema.Resource {
 	return &schema.Resource{
-		Description:   "The Account Authentication resource allows the creation and management of an Aiven Account Authentications.",
+		Description:   "The Account Authentication resource allows the creation and management of an Aiven Account Authentications. The Aiven API supports `internal` and `saml` authentication methods, OpenID Connect (OIDC) identity providers are not supported and are out of scope of this resource.",
 		CreateContext: resourceAccountAuthenticationCreate,
 		ReadContext:   resourceAccountAuthenticationRead,
 		UpdateContext: resourceAccountAuthenticationUpdate,
@@ -87,113 +241,139 @@ func resourceAccountAuthentication() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceAccountAuthenticationState,
 		},
+		CustomizeDiff: resourceAccountAuthenticationCustomizeDiff,
 
 		Schema: aivenAccountAuthenticationSchema,
 	}
 }
 
 func resourceAccountAuthenticationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	accountId := d.Get("account_id").(string)
 
-	r, err := client.AccountAuthentications.Create(
-		accountId,
-		aiven.AccountAuthenticationMethod{
-			Enabled:         d.Get("enabled").(bool),
-			Name:            d.Get("name").(string),
-			Type:            d.Get("type").(string),
-			SAMLCertificate: d.Get("saml_certificate").(string),
-			SAMLIdpUrl:      d.Get("saml_idp_url").(string),
-			SAMLEntity:      d.Get("saml_entity_id").(string),
-		},
-	)
+	method, err := accountAuthenticationMethodFromTerraform(d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	var r struct {
+		AuthenticationMethod accountAuthenticationMethod `json:"authentication_method"`
+	}
+	path := clientRequestPath("account", accountId, "authentication")
+	if err := clientRequest(client, http.MethodPost, path, method, &r); err != nil {
+		return diag.FromErr(err)
+	}
+
 	d.SetId(buildResourceID(
-		r.AuthenticationMethod.AccountId,
-		r.AuthenticationMethod.Id))
+		r.AuthenticationMethod.AccountID,
+		r.AuthenticationMethod.ID))
 
 	return resourceAccountAuthenticationRead(ctx, d, m)
 }
 
 func resourceAccountAuthenticationRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	accountId, authId := splitResourceID2(d.Id())
-	r, err := client.AccountAuthentications.Get(accountId, authId)
+	r, err := getAccountAuthenticationMethod(client, accountId, authId)
 	if err != nil {
 		return diag.FromErr(resourceReadHandleNotFound(err, d))
 	}
 
-	if err := d.Set("account_id", r.AuthenticationMethod.AccountId); err != nil {
+	if err := copyAccountAuthenticationMethodFromAPIResponseToTerraform(d, r); err != nil {
 		return diag.FromErr(err)
 	}
-	if err := d.Set("name", r.AuthenticationMethod.Name); err != nil {
-		return diag.FromErr(err)
+
+	return nil
+}
+
+// copyAccountAuthenticationMethodFromAPIResponseToTerraform sets the fields of an authentication
+// method of an account
+func copyAccountAuthenticationMethodFromAPIResponseToTerraform(d *schema.ResourceData, r *accountAuthenticationMethod) error {
+	if err := d.Set("account_id", r.AccountID); err != nil {
+		return err
 	}
-	if err := d.Set("type", r.AuthenticationMethod.Type); err != nil {
-		return diag.FromErr(err)
+	if err := d.Set("name", r.Name); err != nil {
+		return err
 	}
-	if err := d.Set("enabled", r.AuthenticationMethod.Enabled); err != nil {
-		return diag.FromErr(err)
+	if err := d.Set("type", r.Type); err != nil {
+		return err
 	}
-	if err := d.Set("saml_certificate", r.AuthenticationMethod.SAMLCertificate); err != nil {
-		return diag.FromErr(err)
+	if err := d.Set("enabled", r.Enabled); err != nil {
+		return err
 	}
-	if err := d.Set("saml_idp_url", r.AuthenticationMethod.SAMLCertificate); err != nil {
-		return diag.FromErr(err)
+	if err := d.Set("saml_certificate", r.SAMLCertificate); err != nil {
+		return err
 	}
-	if err := d.Set("saml_entity_id", r.AuthenticationMethod.SAMLCertificate); err != nil {
-		return diag.FromErr(err)
+	if err := d.Set("saml_idp_url", r.SAMLIdpURL); err != nil {
+		return err
 	}
-	if err := d.Set("authentication_id", r.AuthenticationMethod.Id); err != nil {
-		return diag.FromErr(err)
+	if err := d.Set("saml_entity_id", r.SAMLEntityID); err != nil {
+		return err
 	}
-	if err := d.Set("saml_acs_url", r.AuthenticationMethod.SAMLAcsUrl); err != nil {
-		return diag.FromErr(err)
+	if err := d.Set("saml_idp_login_allowed", r.SAMLIdpLoginAllowed); err != nil {
+		return err
 	}
-	if err := d.Set("saml_metadata_url", r.AuthenticationMethod.SAMLMetadataUrl); err != nil {
-		return diag.FromErr(err)
+	if err := d.Set("saml_signature_algorithm", r.SAMLSignatureAlgorithm); err != nil {
+		return err
 	}
-	if err := d.Set("create_time", r.AuthenticationMethod.CreateTime.String()); err != nil {
-		return diag.FromErr(err)
+	if err := d.Set("saml_digest_algorithm", r.SAMLDigestAlgorithm); err != nil {
+		return err
 	}
-	if err := d.Set("update_time", r.AuthenticationMethod.UpdateTime.String()); err != nil {
-		return diag.FromErr(err)
+	if err := d.Set("saml_variant", r.SAMLVariant); err != nil {
+		return err
+	}
+	if err := d.Set("saml_field_mapping", flattenAccountAuthenticationSAMLFieldMapping(r.SAMLFieldMapping)); err != nil {
+		return err
+	}
+	if err := d.Set("auto_join_team_id", stringValue(r.AutoJoinTeamID)); err != nil {
+		return err
+	}
+	if err := d.Set("authentication_id", r.ID); err != nil {
+		return err
+	}
+	if err := d.Set("saml_acs_url", r.SAMLAcsURL); err != nil {
+		return err
+	}
+	if err := d.Set("saml_metadata_url", r.SAMLMetadataURL); err != nil {
+		return err
+	}
+	if err := d.Set("saml_sp_certificate", r.SAMLSpCertificate); err != nil {
+		return err
+	}
+	if err := d.Set("state", r.State); err != nil {
+		return err
+	}
+	if err := d.Set("create_time", r.CreateTime.String()); err != nil {
+		return err
+	}
+	if err := d.Set("update_time", r.UpdateTime.String()); err != nil {
+		return err
 	}
 
 	return nil
 }
 
 func resourceAccountAuthenticationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 	accountId, authId := splitResourceID2(d.Id())
 
-	r, err := client.AccountAuthentications.Update(accountId, aiven.AccountAuthenticationMethod{
-		Id:              authId,
-		Enabled:         d.Get("enabled").(bool),
-		Name:            d.Get("name").(string),
-		Type:            d.Get("type").(string),
-		SAMLCertificate: d.Get("saml_certificate").(string),
-		SAMLIdpUrl:      d.Get("saml_idp_url").(string),
-		SAMLEntity:      d.Get("saml_entity_id").(string),
-	})
+	method, err := accountAuthenticationMethodFromTerraform(d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	d.SetId(buildResourceID(
-		r.AuthenticationMethod.AccountId,
-		r.AuthenticationMethod.Id))
+	path := clientRequestPath("account", accountId, "authentication", authId)
+	if err := clientRequest(client, http.MethodPut, path, method, nil); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return resourceAccountAuthenticationRead(ctx, d, m)
 }
 
 func resourceAccountAuthenticationDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	accountId, teamId := splitResourceID2(d.Id())
 
@@ -213,3 +393,158 @@ func resourceAccountAuthenticationState(ctx context.Context, d *schema.ResourceD
 
 	return []*schema.ResourceData{d}, nil
 }
+
+// resourceAccountAuthenticationCustomizeDiff validates the SAML metadata of the identity provider
+// and plans the settings read from it
+func resourceAccountAuthenticationCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.HasChange("saml_idp_metadata_xml") {
+		return nil
+	}
+
+	if !d.NewValueKnown("saml_idp_metadata_xml") {
+		for _, k := range samlIdpMetadataFields {
+			if err := d.SetNewComputed(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	metadata := d.Get("saml_idp_metadata_xml").(string)
+	if metadata == "" {
+		return nil
+	}
+
+	certificate, url, entityID, err := parseSAMLIdpMetadata(metadata)
+	if err != nil {
+		return err
+	}
+
+	if err := d.SetNew("saml_certificate", certificate); err != nil {
+		return err
+	}
+	if err := d.SetNew("saml_idp_url", url); err != nil {
+		return err
+	}
+
+	return d.SetNew("saml_entity_id", entityID)
+}
+
+// accountAuthenticationMethodFromTerraform builds the authentication method sent to the API,
+// the identity provider settings come from its metadata document when one is given
+func accountAuthenticationMethodFromTerraform(d *schema.ResourceData) (*accountAuthenticationMethod, error) {
+	method := &accountAuthenticationMethod{
+		Enabled:                d.Get("enabled").(bool),
+		Name:                   d.Get("name").(string),
+		Type:                   d.Get("type").(string),
+		SAMLCertificate:        d.Get("saml_certificate").(string),
+		SAMLIdpURL:             d.Get("saml_idp_url").(string),
+		SAMLEntityID:           d.Get("saml_entity_id").(string),
+		SAMLIdpLoginAllowed:    d.Get("saml_idp_login_allowed").(bool),
+		SAMLSignatureAlgorithm: d.Get("saml_signature_algorithm").(string),
+		SAMLDigestAlgorithm:    d.Get("saml_digest_algorithm").(string),
+		SAMLVariant:            d.Get("saml_variant").(string),
+	}
+
+	if v := d.Get("auto_join_team_id").(string); v != "" {
+		method.AutoJoinTeamID = &v
+	}
+
+	if v := d.Get("saml_field_mapping").([]interface{}); len(v) > 0 && v[0] != nil {
+		f := v[0].(map[string]interface{})
+		method.SAMLFieldMapping = &accountAuthenticationSAMLFieldMapping{
+			Email:     f["email"].(string),
+			FirstName: f["first_name"].(string),
+			LastName:  f["last_name"].(string),
+			RealName:  f["real_name"].(string),
+			Identity:  f["identity"].(string),
+			Groups:    f["groups"].(string),
+		}
+	}
+
+	if metadata := d.Get("saml_idp_metadata_xml").(string); metadata != "" {
+		var err error
+		method.SAMLCertificate, method.SAMLIdpURL, method.SAMLEntityID, err = parseSAMLIdpMetadata(metadata)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return method, nil
+}
+
+func flattenAccountAuthenticationSAMLFieldMapping(f *accountAuthenticationSAMLFieldMapping) []map[string]interface{} {
+	if f == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{{
+		"email":      f.Email,
+		"first_name": f.FirstName,
+		"last_name":  f.LastName,
+		"real_name":  f.RealName,
+		"identity":   f.Identity,
+		"groups":     f.Groups,
+	}}
+}
+
+// getAccountAuthenticationMethod returns an authentication method of an account
+func getAccountAuthenticationMethod(client *aiven.Client, accountId, authId string) (*accountAuthenticationMethod, error) {
+	var r struct {
+		AuthenticationMethod accountAuthenticationMethod `json:"authentication_method"`
+	}
+
+	path := clientRequestPath("account", accountId, "authentication", authId)
+	if err := clientRequest(client, http.MethodGet, path, nil, &r); err != nil {
+		return nil, err
+	}
+
+	return &r.AuthenticationMethod, nil
+}
+
+// parseSAMLIdpMetadata reads the signing certificate, the single sign-on URL and the entity id
+// from the SAML metadata document of an identity provider
+func parseSAMLIdpMetadata(metadata string) (certificate, url, entityID string, err error) {
+	var m samlIdpMetadata
+	if err := xml.Unmarshal([]byte(metadata), &m); err != nil {
+		return "", "", "", fmt.Errorf("cannot parse SAML metadata of the identity provider: %w", err)
+	}
+
+	if m.EntityID == "" {
+		return "", "", "", fmt.Errorf("SAML metadata of the identity provider has no entity id")
+	}
+
+	for _, k := range m.IDPSSODescriptor.KeyDescriptors {
+		if k.Use == "" || k.Use == "signing" {
+			certificate = k.Certificate
+			break
+		}
+	}
+	if certificate == "" {
+		return "", "", "", fmt.Errorf("SAML metadata of the identity provider has no signing certificate")
+	}
+
+	der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(certificate), ""))
+	if err != nil {
+		return "", "", "", fmt.Errorf("cannot decode the certificate of the identity provider: %w", err)
+	}
+	if _, err := x509.ParseCertificate(der); err != nil {
+		return "", "", "", fmt.Errorf("cannot parse the certificate of the identity provider: %w", err)
+	}
+
+	// the HTTP-Redirect binding is preferred over the other bindings
+	for _, s := range m.IDPSSODescriptor.SingleSignOnServices {
+		if strings.HasSuffix(s.Binding, ":HTTP-Redirect") {
+			url = s.Location
+			break
+		}
+		if url == "" {
+			url = s.Location
+		}
+	}
+	if url == "" {
+		return "", "", "", fmt.Errorf("SAML metadata of the identity provider has no single sign-on service")
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})), url, m.EntityID, nil
+}
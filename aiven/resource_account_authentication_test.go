@@ -3,13 +3,24 @@
 package aiven
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"log"
+	"math/big"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/aiven/aiven-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -58,7 +69,7 @@ func testAccAccountAuthenticationResource(name string) string {
 }
 
 func testAccCheckAivenAccountAuthenticationResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each account authentication is destroyed
 	for _, rs := range s.RootModule().Resources {
@@ -142,3 +153,111 @@ func testAccCheckAivenAccountAuthenticationAttributes(n string) resource.TestChe
 		return nil
 	}
 }
+
+// testSAMLCertificate returns a self-signed certificate encoded as in SAML metadata documents
+func testSAMLCertificate(t *testing.T) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "idp.example.com"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return base64.StdEncoding.EncodeToString(der)
+}
+
+func Test_parseSAMLIdpMetadata(t *testing.T) {
+	certificate := testSAMLCertificate(t)
+	metadata := func(keys, services string) string {
+		return `<?xml version="1.0"?>
+<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" xmlns:ds="http://www.w3.org/2000/09/xmldsig#" entityID="https://idp.example.com/metadata">
+  <md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">` + keys + services + `
+  </md:IDPSSODescriptor>
+</md:EntityDescriptor>`
+	}
+	signing := `<md:KeyDescriptor use="signing"><ds:KeyInfo><ds:X509Data><ds:X509Certificate>
+` + certificate + `
+</ds:X509Certificate></ds:X509Data></ds:KeyInfo></md:KeyDescriptor>`
+	services := `<md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST" Location="https://idp.example.com/sso/post"/>
+<md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="https://idp.example.com/sso/redirect"/>`
+
+	tests := []struct {
+		name     string
+		metadata string
+		wantURL  string
+		wantErr  bool
+	}{
+		{"valid", metadata(signing, services), "https://idp.example.com/sso/redirect", false},
+		{"no certificate", metadata("", services), "", true},
+		{"encryption certificate only", metadata(strings.Replace(signing, "signing", "encryption", 1), services), "", true},
+		{"no single sign-on service", metadata(signing, ""), "", true},
+		{"invalid document", "<md:EntityDescriptor", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotCertificate, gotURL, gotEntityID, err := parseSAMLIdpMetadata(tt.metadata)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSAMLIdpMetadata() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !strings.HasPrefix(gotCertificate, "-----BEGIN CERTIFICATE-----") {
+				t.Errorf("parseSAMLIdpMetadata() certificate = %v, want a PEM certificate", gotCertificate)
+			}
+			if gotURL != tt.wantURL {
+				t.Errorf("parseSAMLIdpMetadata() url = %v, want %v", gotURL, tt.wantURL)
+			}
+			if gotEntityID != "https://idp.example.com/metadata" {
+				t.Errorf("parseSAMLIdpMetadata() entity id = %v", gotEntityID)
+			}
+		})
+	}
+}
+
+func Test_copyAccountAuthenticationMethodFromAPIResponseToTerraform(t *testing.T) {
+	var r accountAuthenticationMethod
+	err := json.Unmarshal([]byte(`{
+		"account_id": "a1",
+		"authentication_method_id": "am1",
+		"authentication_method_name": "sso",
+		"authentication_method_type": "saml",
+		"authentication_method_enabled": true,
+		"saml_certificate": "certificate",
+		"saml_idp_url": "https://idp.example.com/sso",
+		"saml_entity_id": "https://idp.example.com/metadata",
+		"saml_field_mapping": {"email": "mail"},
+		"auto_join_team_id": "t1",
+		"create_time": "2021-11-01T00:00:00Z",
+		"update_time": "2021-11-01T00:00:00Z"
+	}`), &r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := schema.TestResourceDataRaw(t, aivenAccountAuthenticationSchema, map[string]interface{}{})
+	if err := copyAccountAuthenticationMethodFromAPIResponseToTerraform(d, &r); err != nil {
+		t.Fatalf("copyAccountAuthenticationMethodFromAPIResponseToTerraform() error = %v", err)
+	}
+
+	for k, want := range map[string]string{
+		"saml_certificate":           "certificate",
+		"saml_idp_url":               "https://idp.example.com/sso",
+		"saml_entity_id":             "https://idp.example.com/metadata",
+		"saml_field_mapping.0.email": "mail",
+		"auto_join_team_id":          "t1",
+	} {
+		if got := d.Get(k).(string); got != want {
+			t.Errorf("%s = %v, want %v", k, got, want)
+		}
+	}
+}
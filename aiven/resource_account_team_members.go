@@ -0,0 +1,321 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var aivenAccountTeamMembersSchema = map[string]*schema.Schema{
+	"account_id": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: complex("The unique account id").forceNew().build(),
+	},
+	"team_id": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: complex("An account team id").forceNew().build(),
+	},
+	"user_emails": {
+		Type:        schema.TypeSet,
+		Required:    true,
+		Set:         accountTeamMemberEmailHash,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "Email addresses of all the members of the team. Users that are not members yet are invited, and the members and invitations of other users are removed.",
+	},
+	"accepted_user_emails": {
+		Type:        schema.TypeSet,
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "Email addresses of the users that accepted their invitation and are members of the team",
+	},
+	"pending_user_emails": {
+		Type:        schema.TypeSet,
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "Email addresses of the users that are invited but did not accept their invitation yet",
+	},
+}
+
+func resourceAccountTeamMembers() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+The Account Team Members resource manages all the members of an Aiven Account Team.
+
+The users of ` + "`user_emails`" + ` that are not members of the team are sent an email invitation,
+and the members and invitations of users missing from ` + "`user_emails`" + ` are removed. Invitations
+that expire or are declined show up as a change on the next plan, they are not sent again during
+refresh. When the resource is destroyed only the members and invitations of ` + "`user_emails`" + ` are
+removed. Do not use this resource together with ` + "`aiven_account_team_member`" + ` for the same team.
+`,
+		CreateContext: resourceAccountTeamMembersCreate,
+		ReadContext:   resourceAccountTeamMembersRead,
+		UpdateContext: resourceAccountTeamMembersUpdate,
+		DeleteContext: resourceAccountTeamMembersDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceAccountTeamMembersState,
+		},
+		CustomizeDiff: resourceAccountTeamMembersCustomizeDiff,
+
+		Schema: aivenAccountTeamMembersSchema,
+	}
+}
+
+func resourceAccountTeamMembersCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+	accountId := d.Get("account_id").(string)
+	teamId := d.Get("team_id").(string)
+
+	if err := applyAccountTeamMembers(client, accountId, teamId, flattenToString(d.Get("user_emails").(*schema.Set).List())); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(buildResourceID(accountId, teamId))
+
+	return resourceAccountTeamMembersRead(ctx, d, m)
+}
+
+func resourceAccountTeamMembersRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+	accountId, teamId := splitResourceID2(d.Id())
+
+	members, invites, err := getAccountTeamMembers(client, accountId, teamId)
+	if err != nil {
+		return diag.FromErr(resourceReadHandleNotFound(err, d))
+	}
+
+	// the addresses are spelled as in the manifest
+	configured := flattenToString(d.Get("user_emails").(*schema.Set).List())
+	spelling := func(email string) string {
+		for _, c := range configured {
+			if strings.EqualFold(c, email) {
+				return c
+			}
+		}
+		return email
+	}
+
+	accepted := make([]string, 0, len(members))
+	for _, member := range members {
+		accepted = append(accepted, spelling(member.UserEmail))
+	}
+	pending := make([]string, 0, len(invites))
+	for _, invite := range invites {
+		if !containsEmail(accepted, invite.UserEmail) {
+			pending = append(pending, spelling(invite.UserEmail))
+		}
+	}
+
+	if err := d.Set("account_id", accountId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("team_id", teamId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("user_emails", append(append([]string{}, accepted...), pending...)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("accepted_user_emails", accepted); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("pending_user_emails", pending); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceAccountTeamMembersUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+	accountId, teamId := splitResourceID2(d.Id())
+
+	if err := applyAccountTeamMembers(client, accountId, teamId, flattenToString(d.Get("user_emails").(*schema.Set).List())); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceAccountTeamMembersRead(ctx, d, m)
+}
+
+func resourceAccountTeamMembersDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+	accountId, teamId := splitResourceID2(d.Id())
+
+	members, invites, err := getAccountTeamMembers(client, accountId, teamId)
+	if err != nil {
+		if aiven.IsNotFound(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	// the members and invitations not managed by the resource are kept
+	remove, revoke := accountTeamMembersOf(flattenToString(d.Get("user_emails").(*schema.Set).List()), members, invites)
+	if err := removeAccountTeamMembers(client, accountId, teamId, remove, revoke); err != nil && !aiven.IsNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceAccountTeamMembersState(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if len(strings.Split(d.Id(), "/")) != 2 {
+		return nil, fmt.Errorf("invalid identifier %v, expected <account_id>/<team_id>", d.Id())
+	}
+
+	di := resourceAccountTeamMembersRead(ctx, d, m)
+	if di.HasError() {
+		return nil, fmt.Errorf("cannot get account team members: %v", di)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceAccountTeamMembersCustomizeDiff plans the accepted and pending users as unknown when
+// the members change
+func resourceAccountTeamMembersCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChange("user_emails") {
+		return nil
+	}
+
+	if err := d.SetNewComputed("accepted_user_emails"); err != nil {
+		return err
+	}
+
+	return d.SetNewComputed("pending_user_emails")
+}
+
+// getAccountTeamMembers returns the members and the pending invitations of an account team
+func getAccountTeamMembers(client *aiven.Client, accountId, teamId string) ([]aiven.AccountTeamMember, []aiven.AccountTeamInvite, error) {
+	rm, err := client.AccountTeamMembers.List(accountId, teamId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ri, err := client.AccountTeamInvites.List(accountId, teamId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return rm.Members, ri.Invites, nil
+}
+
+// applyAccountTeamMembers invites the users missing from an account team and removes the
+// members and invitations of the other users
+func applyAccountTeamMembers(client *aiven.Client, accountId, teamId string, userEmails []string) error {
+	members, invites, err := getAccountTeamMembers(client, accountId, teamId)
+	if err != nil {
+		return err
+	}
+
+	invite, remove, revoke := accountTeamMembersChanges(userEmails, members, invites)
+
+	if err := removeAccountTeamMembers(client, accountId, teamId, remove, revoke); err != nil {
+		return err
+	}
+
+	for _, email := range invite {
+		if err := client.AccountTeamMembers.Invite(accountId, teamId, email); err != nil {
+			return fmt.Errorf("cannot invite %s: %w", email, err)
+		}
+	}
+
+	return nil
+}
+
+// removeAccountTeamMembers removes members from an account team and revokes invitations
+func removeAccountTeamMembers(
+	client *aiven.Client, accountId, teamId string, remove []aiven.AccountTeamMember, revoke []string) error {
+	for _, email := range revoke {
+		if err := client.AccountTeamInvites.Delete(accountId, teamId, email); err != nil && !aiven.IsNotFound(err) {
+			return fmt.Errorf("cannot revoke the invitation of %s: %w", email, err)
+		}
+	}
+
+	for _, member := range remove {
+		if err := client.AccountTeamMembers.Delete(accountId, teamId, member.UserId); err != nil && !aiven.IsNotFound(err) {
+			return fmt.Errorf("cannot remove team member %s: %w", member.UserEmail, err)
+		}
+	}
+
+	return nil
+}
+
+// accountTeamMembersChanges returns the users to invite, the members to remove and the
+// invitations to revoke for an account team to have exactly the given members
+func accountTeamMembersChanges(
+	userEmails []string,
+	members []aiven.AccountTeamMember,
+	invites []aiven.AccountTeamInvite,
+) (invite []string, remove []aiven.AccountTeamMember, revoke []string) {
+	var current []string
+
+	for _, member := range members {
+		current = append(current, member.UserEmail)
+		if !containsEmail(userEmails, member.UserEmail) {
+			remove = append(remove, member)
+		}
+	}
+
+	for _, i := range invites {
+		current = append(current, i.UserEmail)
+		if !containsEmail(userEmails, i.UserEmail) {
+			revoke = append(revoke, i.UserEmail)
+		}
+	}
+
+	for _, email := range userEmails {
+		if !containsEmail(current, email) && !containsEmail(invite, email) {
+			invite = append(invite, email)
+		}
+	}
+	sort.Strings(invite)
+
+	return invite, remove, revoke
+}
+
+// accountTeamMembersOf returns the members and the invitations of an account team that belong
+// to the given users
+func accountTeamMembersOf(
+	userEmails []string,
+	members []aiven.AccountTeamMember,
+	invites []aiven.AccountTeamInvite,
+) (remove []aiven.AccountTeamMember, revoke []string) {
+	for _, member := range members {
+		if containsEmail(userEmails, member.UserEmail) {
+			remove = append(remove, member)
+		}
+	}
+
+	for _, i := range invites {
+		if containsEmail(userEmails, i.UserEmail) {
+			revoke = append(revoke, i.UserEmail)
+		}
+	}
+
+	return remove, revoke
+}
+
+// containsEmail reports whether a list of email addresses contains an address, email addresses
+// are case insensitive
+func containsEmail(emails []string, email string) bool {
+	for _, e := range emails {
+		if strings.EqualFold(e, email) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func accountTeamMemberEmailHash(v interface{}) int {
+	return schema.HashString(strings.ToLower(v.(string)))
+}
@@ -0,0 +1,206 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccAivenAccountTeamMembers_basic(t *testing.T) {
+	resourceName := "aiven_account_team_members.foo"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenAccountTeamMembersResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAccountTeamMembersResource(rName, "1", "2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "user_emails.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "pending_user_emails.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "accepted_user_emails.#", "0"),
+				),
+			},
+			{
+				Config: testAccAccountTeamMembersResource(rName, "2", "3"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "user_emails.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "pending_user_emails.#", "2"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccAccountTeamMembersResource(name string, users ...string) string {
+	emails := ""
+	for _, u := range users {
+		emails += fmt.Sprintf(`"ivan.savciuc+%s-%s@aiven.fi",`, name, u)
+	}
+
+	return fmt.Sprintf(`
+		resource "aiven_account" "foo" {
+			name = "test-acc-ac-%s"
+		}
+
+		resource "aiven_account_team" "foo" {
+			account_id = aiven_account.foo.account_id
+			name = "test-acc-team-%s"
+		}
+
+		resource "aiven_account_team_members" "foo" {
+			account_id = aiven_account_team.foo.account_id
+			team_id = aiven_account_team.foo.team_id
+			user_emails = [%s]
+		}
+		`, name, name, emails)
+}
+
+func testAccCheckAivenAccountTeamMembersResourceDestroy(s *terraform.State) error {
+	c := testAccProvider.Meta().(*providerMeta).client
+
+	// loop through the resources in state, verifying each account team has no members left
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aiven_account_team_members" {
+			continue
+		}
+
+		accountId, teamId := splitResourceID2(rs.Primary.ID)
+		members, invites, err := getAccountTeamMembers(c, accountId, teamId)
+		if err != nil {
+			if aiven.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		if len(members) > 0 || len(invites) > 0 {
+			return fmt.Errorf("account team (%s) still has members", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func Test_accountTeamMembersChanges(t *testing.T) {
+	members := []aiven.AccountTeamMember{
+		{UserId: "u1", UserEmail: "alice@example.com"},
+		{UserId: "u2", UserEmail: "bob@example.com"},
+	}
+	invites := []aiven.AccountTeamInvite{
+		{UserEmail: "carol@example.com"},
+		{UserEmail: "dave@example.com"},
+	}
+
+	tests := []struct {
+		name       string
+		userEmails []string
+		wantInvite []string
+		wantRemove []aiven.AccountTeamMember
+		wantRevoke []string
+	}{
+		{
+			"unchanged",
+			[]string{"alice@example.com", "bob@example.com", "carol@example.com", "dave@example.com"},
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"case insensitive",
+			[]string{"Alice@example.com", "bob@example.com", "carol@example.com", "DAVE@example.com"},
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"invite, remove and revoke",
+			[]string{"alice@example.com", "erin@example.com", "carol@example.com", "erin@example.com"},
+			[]string{"erin@example.com"},
+			[]aiven.AccountTeamMember{{UserId: "u2", UserEmail: "bob@example.com"}},
+			[]string{"dave@example.com"},
+		},
+		{
+			"remove all",
+			nil,
+			nil,
+			members,
+			[]string{"carol@example.com", "dave@example.com"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invite, remove, revoke := accountTeamMembersChanges(tt.userEmails, members, invites)
+			if !reflect.DeepEqual(invite, tt.wantInvite) {
+				t.Errorf("accountTeamMembersChanges() invite = %v, want %v", invite, tt.wantInvite)
+			}
+			if !reflect.DeepEqual(remove, tt.wantRemove) {
+				t.Errorf("accountTeamMembersChanges() remove = %v, want %v", remove, tt.wantRemove)
+			}
+			if !reflect.DeepEqual(revoke, tt.wantRevoke) {
+				t.Errorf("accountTeamMembersChanges() revoke = %v, want %v", revoke, tt.wantRevoke)
+			}
+		})
+	}
+}
+
+func Test_accountTeamMembersOf(t *testing.T) {
+	members := []aiven.AccountTeamMember{
+		{UserId: "u1", UserEmail: "alice@example.com"},
+		{UserId: "u2", UserEmail: "bob@example.com"},
+	}
+	invites := []aiven.AccountTeamInvite{
+		{UserEmail: "carol@example.com"},
+		{UserEmail: "dave@example.com"},
+	}
+
+	tests := []struct {
+		name       string
+		userEmails []string
+		wantRemove []aiven.AccountTeamMember
+		wantRevoke []string
+	}{
+		{
+			"none",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"managed only",
+			[]string{"Alice@example.com", "DAVE@example.com", "erin@example.com"},
+			[]aiven.AccountTeamMember{{UserId: "u1", UserEmail: "alice@example.com"}},
+			[]string{"dave@example.com"},
+		},
+		{
+			"all",
+			[]string{"alice@example.com", "bob@example.com", "carol@example.com", "dave@example.com"},
+			members,
+			[]string{"carol@example.com", "dave@example.com"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remove, revoke := accountTeamMembersOf(tt.userEmails, members, invites)
+			if !reflect.DeepEqual(remove, tt.wantRemove) {
+				t.Errorf("accountTeamMembersOf() remove = %v, want %v", remove, tt.wantRemove)
+			}
+			if !reflect.DeepEqual(revoke, tt.wantRevoke) {
+				t.Errorf("accountTeamMembersOf() revoke = %v, want %v", revoke, tt.wantRevoke)
+			}
+		})
+	}
+}
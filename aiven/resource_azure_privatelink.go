@@ -68,7 +68,7 @@ func resourceAzurePrivatelink() *schema.Resource {
 }
 
 func resourceAzurePrivatelinkCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	var subscriptionIDs []string
 	var project = d.Get("project").(string)
@@ -99,7 +99,7 @@ func resourceAzurePrivatelinkCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceAzurePrivatelinkRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 	project, serviceName := splitResourceID2(d.Id())
 
 	pl, err := client.AzurePrivatelink.Get(project, serviceName)
@@ -132,7 +132,7 @@ func resourceAzurePrivatelinkRead(_ context.Context, d *schema.ResourceData, m i
 	return nil
 }
 func resourceAzurePrivatelinkUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	var subscriptionIDs []string
 	project, serviceName := splitResourceID2(d.Id())
@@ -181,7 +181,7 @@ func waitForAzurePrivatelinkToBeActive(client *aiven.Client, project string, ser
 }
 
 func resourceAzurePrivatelinkDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 	project, serviceName := splitResourceID2(d.Id())
 
 	err := client.AzurePrivatelink.Delete(project, serviceName)
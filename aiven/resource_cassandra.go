@@ -30,6 +30,7 @@ func resourceCassandra() *schema.Resource {
 		ReadContext:   resourceServiceRead,
 		UpdateContext: resourceServiceUpdate,
 		DeleteContext: resourceServiceDelete,
+		CustomizeDiff: resourceServiceCustomizeDiffWrapper(ServiceTypeCassandra),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceState,
 		},
@@ -71,7 +71,7 @@ func resourceConnectionPool() *schema.Resource {
 }
 
 func resourceConnectionPoolCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
@@ -97,12 +97,12 @@ func resourceConnectionPoolCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceConnectionPoolRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project, serviceName, poolName := splitResourceID3(d.Id())
 	pool, err := client.ConnectionPools.Get(project, serviceName, poolName)
 	if err != nil {
-		return diag.FromErr(resourceReadHandleNotFound(err, d))
+		return diag.FromErr(resourceReadHandleServicePoweredOff(err, d, client, project, serviceName))
 	}
 
 	err = copyConnectionPoolPropertiesFromAPIResponseToTerraform(d, pool, project, serviceName)
@@ -114,7 +114,7 @@ func resourceConnectionPoolRead(_ context.Context, d *schema.ResourceData, m int
 }
 
 func resourceConnectionPoolUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project, serviceName, poolName := splitResourceID3(d.Id())
 	_, err := client.ConnectionPools.Update(
@@ -136,7 +136,7 @@ func resourceConnectionPoolUpdate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceConnectionPoolDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, serviceName, poolName := splitResourceID3(d.Id())
 	err := client.ConnectionPools.Delete(projectName, serviceName, poolName)
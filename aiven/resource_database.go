@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const defaultLC = "en_US.UTF-8"
@@ -55,11 +56,73 @@ var aivenDatabaseSchema = map[string]*schema.Schema{
 		Default:     false,
 		Description: complex(`It is a Terraform client-side deletion protections, which prevents the database from being deleted by Terraform. It is recommended to enable this for any production databases containing critical data.`).defaultValue(false).build(),
 	},
+	"owner": {
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validation.StringIsNotEmpty,
+		Description: "PostgreSQL specific field, the user owning the database. The admin user of the service is made a member " +
+			"of the owner, which PostgreSQL requires to transfer the database and to set default privileges for the owner. " +
+			"The admin user keeps owning the database when not set.",
+	},
+	"extensions": {
+		Type:     schema.TypeSet,
+		Optional: true,
+		Description: "PostgreSQL specific field, extensions enabled in the database, such as `pg_stat_statements`, `postgis` " +
+			"or `timescaledb`. Extensions not in the field, also those created outside of Terraform, are dropped, which fails " +
+			"when objects of the database depend on them.",
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+	},
+	"default_privileges": {
+		Type:     schema.TypeSet,
+		Optional: true,
+		Description: "PostgreSQL specific field, privileges granted on objects created later in the database by the owner, " +
+			"the admin user when `owner` is not set. Default privileges changed outside of Terraform are not detected.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"role": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+					Description:  "The user or role the privileges are granted to.",
+				},
+				"schema": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Default:     "public",
+					Description: complex("The schema of the objects.").defaultValue("public").build(),
+				},
+				"object_type": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice([]string{"TABLES", "SEQUENCES", "FUNCTIONS", "TYPES"}, false),
+					Description:  complex("The type of the objects.").possibleValues("TABLES", "SEQUENCES", "FUNCTIONS", "TYPES").build(),
+				},
+				"privileges": {
+					Type:     schema.TypeSet,
+					Required: true,
+					MinItems: 1,
+					Description: "The privileges granted. Tables support `ALL`, `SELECT`, `INSERT`, `UPDATE`, `DELETE`, `TRUNCATE`, " +
+						"`REFERENCES` and `TRIGGER`, sequences `ALL`, `SELECT`, `UPDATE` and `USAGE`, functions `ALL` and `EXECUTE` " +
+						"and types `ALL` and `USAGE`.",
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+						ValidateFunc: validation.StringInSlice([]string{
+							"ALL", "SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER", "USAGE", "EXECUTE",
+						}, false),
+					},
+				},
+			},
+		},
+	},
 }
 
 func resourceDatabase() *schema.Resource {
 	return &schema.Resource{
-		Description:   "The Database resource allows the creation and management of Aiven Databases.",
+		Description:   "The Database resource allows the creation and management of Aiven Databases. The owner, extensions and default privileges of PostgreSQL databases are managed over a connection to the service, which has to be reachable from where Terraform runs.",
 		CreateContext: resourceDatabaseCreate,
 		ReadContext:   resourceDatabaseRead,
 		DeleteContext: resourceDatabaseDelete,
@@ -77,7 +140,7 @@ func resourceDatabase() *schema.Resource {
 }
 
 func resourceDatabaseCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
@@ -97,20 +160,37 @@ func resourceDatabaseCreate(ctx context.Context, d *schema.ResourceData, m inter
 
 	d.SetId(buildResourceID(projectName, serviceName, databaseName))
 
+	pg := expandDatabasePGConfiguration(d.Get("owner"), d.Get("extensions"), d.Get("default_privileges"))
+	if err := resourceDatabaseApplyPGConfiguration(ctx, d, m, databasePGConfiguration{}, pg); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return resourceDatabaseRead(ctx, d, m)
 }
 
 func resourceDatabaseUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.HasChanges("owner", "extensions", "default_privileges") {
+		oldOwner, newOwner := d.GetChange("owner")
+		oldExtensions, newExtensions := d.GetChange("extensions")
+		oldDefaultPrivileges, newDefaultPrivileges := d.GetChange("default_privileges")
+
+		old := expandDatabasePGConfiguration(oldOwner, oldExtensions, oldDefaultPrivileges)
+		new := expandDatabasePGConfiguration(newOwner, newExtensions, newDefaultPrivileges)
+		if err := resourceDatabaseApplyPGConfiguration(ctx, d, m, old, new); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceDatabaseRead(ctx, d, m)
 }
 
-func resourceDatabaseRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+func resourceDatabaseRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
 
 	projectName, serviceName, databaseName := splitResourceID3(d.Id())
 	database, err := client.Databases.Get(projectName, serviceName, databaseName)
 	if err != nil {
-		return diag.FromErr(resourceReadHandleNotFound(err, d))
+		return diag.FromErr(resourceReadHandleServicePoweredOff(err, d, client, projectName, serviceName))
 	}
 
 	if err := d.Set("database_name", database.DatabaseName); err != nil {
@@ -131,12 +211,15 @@ func resourceDatabaseRead(_ context.Context, d *schema.ResourceData, m interface
 	if err := d.Set("termination_protection", d.Get("termination_protection")); err != nil {
 		return diag.FromErr(err)
 	}
+	if err := copyDatabasePGConfigurationFromDatabaseToTerraform(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return nil
 }
 
 func resourceDatabaseDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, serviceName, databaseName := splitResourceID3(d.Id())
 
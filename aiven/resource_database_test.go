@@ -106,8 +106,43 @@ func TestAccAivenDatabase_basic(t *testing.T) {
 	})
 }
 
+// TestAccAivenDatabase_pg checks that the owner and extensions of a PostgreSQL database are
+// applied and read back from the database
+func TestAccAivenDatabase_pg(t *testing.T) {
+	resourceName := "aiven_database.foo"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenDatabaseResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDatabasePGResource(rName, "pg_stat_statements"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "owner", fmt.Sprintf("user-%s", rName)),
+					resource.TestCheckResourceAttr(resourceName, "extensions.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "extensions.*", "pg_stat_statements"),
+					resource.TestCheckResourceAttr(resourceName, "default_privileges.#", "1"),
+				),
+			},
+			{
+				Config: testAccDatabasePGResource(rName, "postgis"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "extensions.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "extensions.*", "postgis"),
+				),
+			},
+			{
+				Config:   testAccDatabasePGResource(rName, "postgis"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
 func testAccCheckAivenDatabaseResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each database is destroyed
 	for _, rs := range s.RootModule().Resources {
@@ -175,6 +210,47 @@ func testAccDatabaseResource(name string) string {
 		`, os.Getenv("AIVEN_PROJECT_NAME"), name, name)
 }
 
+func testAccDatabasePGResource(name, extension string) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_pg" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-4"
+			service_name = "test-acc-sr-%s"
+		}
+
+		resource "aiven_service_user" "owner" {
+			project = aiven_pg.bar.project
+			service_name = aiven_pg.bar.service_name
+			username = "user-%s"
+		}
+
+		resource "aiven_service_user" "reader" {
+			project = aiven_pg.bar.project
+			service_name = aiven_pg.bar.service_name
+			username = "user-reader-%s"
+		}
+
+		resource "aiven_database" "foo" {
+			project = aiven_pg.bar.project
+			service_name = aiven_pg.bar.service_name
+			database_name = "test-acc-db-%s"
+			owner = aiven_service_user.owner.username
+			extensions = ["%s"]
+
+			default_privileges {
+				role = aiven_service_user.reader.username
+				object_type = "TABLES"
+				privileges = ["SELECT"]
+			}
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name, name, name, name, extension)
+}
+
 func testAccDatabaseTerminationProtectionResource(name string) string {
 	return fmt.Sprintf(`
 		data "aiven_project" "foo" {
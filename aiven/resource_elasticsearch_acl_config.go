@@ -6,7 +6,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/aiven/aiven-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -44,7 +43,7 @@ func resourceElasticsearchACLConfig() *schema.Resource {
 }
 
 func resourceElasticsearchACLConfigRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project, serviceName := splitResourceID2(d.Id())
 	r, err := client.ElasticsearchACLs.Get(project, serviceName)
@@ -77,7 +76,7 @@ func resourceElasticsearchACLConfigState(ctx context.Context, d *schema.Resource
 }
 
 func resourceElasticsearchACLConfigUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
@@ -94,7 +93,7 @@ func resourceElasticsearchACLConfigUpdate(ctx context.Context, d *schema.Resourc
 }
 
 func resourceElasticsearchACLConfigDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
@@ -67,7 +67,7 @@ func resourceElasticsearchACLRuleGetPermissionFromACLResponse(cfg aiven.ElasticS
 }
 
 func resourceElasticsearchACLRuleRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project, serviceName, username, index := splitResourceID4(d.Id())
 	r, err := client.ElasticsearchACLs.Get(project, serviceName)
@@ -120,7 +120,7 @@ func resourceElasticsearchACLRuleMkAivenACL(username, index, permission string)
 }
 
 func resourceElasticsearchACLRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
@@ -140,7 +140,7 @@ func resourceElasticsearchACLRuleUpdate(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceElasticsearchACLRuleDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
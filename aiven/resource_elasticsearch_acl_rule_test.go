@@ -75,7 +75,7 @@ func testAccElasticsearchACLRuleResource(name string) string {
 }
 
 func testAccCheckAivenElasticsearchACLRuleResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each OS ACL is destroyed
 	for _, rs := range s.RootModule().Resources {
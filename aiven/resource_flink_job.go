@@ -64,7 +64,7 @@ func resourceFlinkJob() *schema.Resource {
 }
 
 func resourceFlinkJobRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project, serviceName, jobId := splitResourceID3(d.Id())
 
@@ -101,7 +101,7 @@ func resourceFlinkJobRead(_ context.Context, d *schema.ResourceData, m interface
 }
 
 func resourceFlinkJobCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
@@ -159,7 +159,7 @@ func resourceFlinkJobCreate(ctx context.Context, d *schema.ResourceData, m inter
 }
 
 func resourceFlinkJobDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project, serviceName, jobId := splitResourceID3(d.Id())
 
@@ -74,7 +74,7 @@ func resourceFlinkTable() *schema.Resource {
 }
 
 func resourceFlinkTableRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project, serviceName, tableId := splitResourceID3(d.Id())
 
@@ -103,7 +103,7 @@ func resourceFlinkTableRead(_ context.Context, d *schema.ResourceData, m interfa
 }
 
 func resourceFlinkTableCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
@@ -136,7 +136,7 @@ func resourceFlinkTableCreate(ctx context.Context, d *schema.ResourceData, m int
 }
 
 func resourceFlinkTableDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project, serviceName, tableId := splitResourceID3(d.Id())
 
@@ -446,7 +446,7 @@ resource "aiven_flink_job" "testing" {
 }
 
 func testAccCheckAivenFlinkJobsAndTableResourcesDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each job and table is destroyed
 	for _, rs := range s.RootModule().Resources {
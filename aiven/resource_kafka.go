@@ -77,6 +77,7 @@ func resourceKafka() *schema.Resource {
 		ReadContext:   resourceServiceRead,
 		UpdateContext: resourceServiceUpdate,
 		DeleteContext: resourceServiceDelete,
+		CustomizeDiff: resourceServiceCustomizeDiffWrapper(ServiceTypeKafka),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceState,
 		},
@@ -96,7 +97,7 @@ func resourceKafkaCreate(ctx context.Context, d *schema.ResourceData, m interfac
 
 	// if default_acl=false delete default wildcard Kafka ACL that is automatically created
 	if !d.Get("default_acl").(bool) {
-		client := m.(*aiven.Client)
+		client := m.(*providerMeta).client
 		project := d.Get("project").(string)
 		serviceName := d.Get("service_name").(string)
 
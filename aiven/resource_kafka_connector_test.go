@@ -115,7 +115,7 @@ func TestAccAivenKafkaConnector_mogosink(t *testing.T) {
 }
 
 func testAccCheckAivenKafkaConnectorResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each aiven_kafka_connector is destroyed
 	for _, rs := range s.RootModule().Resources {
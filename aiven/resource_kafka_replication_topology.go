@@ -0,0 +1,778 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const identityReplicationPolicy = "org.apache.kafka.connect.mirror.IdentityReplicationPolicy"
+
+var aivenKafkaReplicationTopologySchema = map[string]*schema.Schema{
+	"project":      commonSchemaProjectReference,
+	"service_name": commonSchemaServiceNameReference,
+
+	"cluster": {
+		Type:        schema.TypeSet,
+		Required:    true,
+		MinItems:    2,
+		Description: "Kafka clusters taking part in the replication topology. A `kafka_mirrormaker` service integration is managed for each of them.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"alias": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringLenBetween(1, 128),
+					Description:  complex("Cluster alias used in the replication flows.").maxLen(128).build(),
+				},
+				"service_name": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: complex("Name of the Kafka service.").referenced().build(),
+				},
+			},
+		},
+	},
+	"flow": {
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Description: "Replication directions between cluster aliases. When not set, every cluster replicates to every other cluster (full mesh).",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"source_cluster": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Source cluster alias.",
+				},
+				"target_cluster": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Target cluster alias.",
+				},
+			},
+		},
+	},
+	"enable": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     true,
+		Description: complex("Enable or disable all replication flows of the topology.").defaultValue(true).build(),
+	},
+	"topics": {
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "List of topics and/or regular expressions to replicate",
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	},
+	"topics_blacklist": {
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "List of topics and/or regular expressions to not replicate.",
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	},
+	"replication_policy_class": {
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      defaultReplicationPolicy,
+		ValidateFunc: validation.StringInSlice(replicationPolicies, false),
+		Description: complex("Replication policy class. Replication cycles are rejected when " +
+			"`IdentityReplicationPolicy` is used since topics would be replicated back to their origin.").
+			defaultValue(defaultReplicationPolicy).possibleValues(stringSliceToInterfaceSlice(replicationPolicies)...).build(),
+	},
+	"sync_group_offsets_enabled": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: complex("Sync consumer group offsets.").defaultValue(false).build(),
+	},
+	"sync_group_offsets_interval_seconds": {
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ValidateFunc: validation.IntAtLeast(1),
+		Default:      1,
+		Description:  complex("Frequency of consumer group offset sync.").defaultValue(1).build(),
+	},
+	"emit_heartbeats_enabled": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: complex("Emit heartbeats enabled.").defaultValue(false).build(),
+	},
+	"integration_ids": {
+		Type:        schema.TypeMap,
+		Computed:    true,
+		Description: "Service integration ids keyed by cluster alias.",
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	},
+	"replication_flows": {
+		Type:        schema.TypeSet,
+		Computed:    true,
+		Description: "Replication flows managed by the topology.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"source_cluster": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Source cluster alias.",
+				},
+				"target_cluster": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Target cluster alias.",
+				},
+			},
+		},
+	},
+}
+
+func resourceKafkaReplicationTopology() *schema.Resource {
+	return &schema.Resource{
+		Description: "The Kafka Replication Topology resource manages MirrorMaker 2 cluster integrations and " +
+			"replication flows of a Kafka MirrorMaker service as a single unit. Only the integrations and flows " +
+			"created by the resource are changed or deleted, importing the resource takes over all " +
+			"`kafka_mirrormaker` integrations of the service and the flows between them.",
+		CreateContext: resourceKafkaReplicationTopologyCreate,
+		ReadContext:   resourceKafkaReplicationTopologyRead,
+		UpdateContext: resourceKafkaReplicationTopologyUpdate,
+		DeleteContext: resourceKafkaReplicationTopologyDelete,
+		CustomizeDiff: resourceKafkaReplicationTopologyCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKafkaReplicationTopologyState,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: aivenKafkaReplicationTopologySchema,
+	}
+}
+
+// replicationEdge is a single directed replication flow between two cluster aliases
+type replicationEdge struct {
+	source string
+	target string
+}
+
+func (e replicationEdge) String() string {
+	return e.source + " -> " + e.target
+}
+
+// replicationTopologyEdges returns the replication flows of a topology, either the explicitly
+// requested ones or a full mesh between all clusters, sorted for a stable order
+func replicationTopologyEdges(aliases []string, explicit []replicationEdge) []replicationEdge {
+	var edges []replicationEdge
+	if len(explicit) > 0 {
+		edges = append(edges, explicit...)
+	} else {
+		for _, source := range aliases {
+			for _, target := range aliases {
+				if source != target {
+					edges = append(edges, replicationEdge{source: source, target: target})
+				}
+			}
+		}
+	}
+
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].source != edges[j].source {
+			return edges[i].source < edges[j].source
+		}
+		return edges[i].target < edges[j].target
+	})
+
+	return edges
+}
+
+// replicationTopologyCycle returns the aliases forming a replication cycle, with the first
+// alias repeated at the end, or nil when the flows form no cycle
+func replicationTopologyCycle(edges []replicationEdge) []string {
+	const (
+		unvisited = iota
+		inProgress
+		done
+	)
+
+	adjacency := make(map[string][]string)
+	var nodes []string
+	for _, e := range edges {
+		if _, ok := adjacency[e.source]; !ok {
+			nodes = append(nodes, e.source)
+		}
+		adjacency[e.source] = append(adjacency[e.source], e.target)
+	}
+	sort.Strings(nodes)
+
+	state := make(map[string]int)
+	var path []string
+	var visit func(node string) []string
+	visit = func(node string) []string {
+		state[node] = inProgress
+		path = append(path, node)
+
+		for _, next := range adjacency[node] {
+			switch state[next] {
+			case inProgress:
+				for i := range path {
+					if path[i] == next {
+						return append(append([]string{}, path[i:]...), next)
+					}
+				}
+			case unvisited:
+				if cycle := visit(next); cycle != nil {
+					return cycle
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[node] = done
+		return nil
+	}
+
+	for _, node := range nodes {
+		if state[node] == unvisited {
+			if cycle := visit(node); cycle != nil {
+				return cycle
+			}
+		}
+	}
+
+	return nil
+}
+
+func replicationTopologyEdgesEqual(a, b []replicationEdge) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func expandReplicationTopologyClusters(clusters *schema.Set) map[string]string {
+	res := make(map[string]string)
+	for _, c := range clusters.List() {
+		cluster := c.(map[string]interface{})
+		res[cluster["alias"].(string)] = cluster["service_name"].(string)
+	}
+	return res
+}
+
+func expandReplicationTopologyEdges(flows *schema.Set) []replicationEdge {
+	var res []replicationEdge
+	for _, f := range flows.List() {
+		flow := f.(map[string]interface{})
+		res = append(res, replicationEdge{
+			source: flow["source_cluster"].(string),
+			target: flow["target_cluster"].(string),
+		})
+	}
+	return res
+}
+
+// replicationTopologyConfiguredEdges returns the flows to set as the `flow` blocks of a topology,
+// none when `flow` is not configured and the flows are the full mesh of the clusters
+func replicationTopologyConfiguredEdges(aliases []string, edges []replicationEdge, configured bool) []replicationEdge {
+	if !configured && replicationTopologyEdgesEqual(replicationTopologyEdges(aliases, nil), edges) {
+		return nil
+	}
+
+	return edges
+}
+
+func flattenReplicationTopologyEdges(edges []replicationEdge) []map[string]interface{} {
+	res := make([]map[string]interface{}, 0, len(edges))
+	for _, e := range edges {
+		res = append(res, map[string]interface{}{
+			"source_cluster": e.source,
+			"target_cluster": e.target,
+		})
+	}
+	return res
+}
+
+func expandReplicationTopologyIDs(ids map[string]interface{}) map[string]string {
+	res := make(map[string]string)
+	for alias, id := range ids {
+		res[alias] = id.(string)
+	}
+	return res
+}
+
+func sortedAliases(clusters map[string]string) []string {
+	aliases := make([]string, 0, len(clusters))
+	for alias := range clusters {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	return aliases
+}
+
+func resourceKafkaReplicationTopologyCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.HasChange("cluster") {
+		if err := d.SetNewComputed("integration_ids"); err != nil {
+			return err
+		}
+	}
+
+	if !d.NewValueKnown("cluster") || !d.NewValueKnown("flow") {
+		return d.SetNewComputed("replication_flows")
+	}
+
+	clusterList := d.Get("cluster").(*schema.Set).List()
+	clusters := expandReplicationTopologyClusters(d.Get("cluster").(*schema.Set))
+	if len(clusters) != len(clusterList) {
+		return fmt.Errorf("cluster aliases must be unique")
+	}
+
+	services := make(map[string]string)
+	for alias, serviceName := range clusters {
+		if other, ok := services[serviceName]; ok {
+			return fmt.Errorf("service %s is used by both %s and %s cluster aliases", serviceName, alias, other)
+		}
+		services[serviceName] = alias
+	}
+
+	explicit := expandReplicationTopologyEdges(d.Get("flow").(*schema.Set))
+	for _, e := range explicit {
+		if e.source == e.target {
+			return fmt.Errorf("replication flow %s cannot replicate a cluster to itself", e)
+		}
+		if _, ok := clusters[e.source]; !ok {
+			return fmt.Errorf("replication flow %s references an unknown source cluster alias %s", e, e.source)
+		}
+		if _, ok := clusters[e.target]; !ok {
+			return fmt.Errorf("replication flow %s references an unknown target cluster alias %s", e, e.target)
+		}
+	}
+
+	edges := replicationTopologyEdges(sortedAliases(clusters), explicit)
+	if d.Get("replication_policy_class").(string) == identityReplicationPolicy {
+		if cycle := replicationTopologyCycle(edges); cycle != nil {
+			return fmt.Errorf("replication cycle %s is not allowed with %s, topics would be replicated "+
+				"back to their source cluster; use %s or remove one of the flows",
+				strings.Join(cycle, " -> "), identityReplicationPolicy, defaultReplicationPolicy)
+		}
+	}
+
+	current := replicationTopologyEdges(nil, expandReplicationTopologyEdges(d.Get("replication_flows").(*schema.Set)))
+	if !replicationTopologyEdgesEqual(current, edges) {
+		return d.SetNew("replication_flows", flattenReplicationTopologyEdges(edges))
+	}
+
+	return nil
+}
+
+func kafkaReplicationTopologyFlowRequest(d *schema.ResourceData, e replicationEdge) aiven.MirrorMakerReplicationFlowRequest {
+	return aiven.MirrorMakerReplicationFlowRequest{
+		ReplicationFlow: aiven.ReplicationFlow{
+			Enabled:                         d.Get("enable").(bool),
+			SourceCluster:                   e.source,
+			TargetCluster:                   e.target,
+			Topics:                          flattenToString(d.Get("topics").([]interface{})),
+			TopicsBlacklist:                 flattenToString(d.Get("topics_blacklist").([]interface{})),
+			ReplicationPolicyClass:          d.Get("replication_policy_class").(string),
+			SyncGroupOffsetsEnabled:         d.Get("sync_group_offsets_enabled").(bool),
+			SyncGroupOffsetsIntervalSeconds: d.Get("sync_group_offsets_interval_seconds").(int),
+			EmitHeartbeatsEnabled:           d.Get("emit_heartbeats_enabled").(bool),
+		},
+	}
+}
+
+// kafkaReplicationTopologyIntegrations returns the kafka_mirrormaker integration ids of the
+// MirrorMaker service keyed by cluster alias together with the aliased service names
+func kafkaReplicationTopologyIntegrations(client *aiven.Client, project, serviceName string) (map[string]string, map[string]string, error) {
+	integrations, err := client.ServiceIntegrations.List(project, serviceName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ids := make(map[string]string)
+	clusters := make(map[string]string)
+	for _, i := range integrations {
+		if i.IntegrationType != "kafka_mirrormaker" || i.SourceService == nil ||
+			i.DestinationService == nil || *i.DestinationService != serviceName {
+			continue
+		}
+
+		alias, ok := i.UserConfig["cluster_alias"].(string)
+		if !ok || alias == "" {
+			continue
+		}
+
+		ids[alias] = i.ServiceIntegrationID
+		clusters[alias] = *i.SourceService
+	}
+
+	return ids, clusters, nil
+}
+
+// trackedReplicationTopologyIntegrations limits the integrations of a MirrorMaker service to the
+// ones tracked by a topology, an integration replaced outside of the topology is not tracked
+func trackedReplicationTopologyIntegrations(ids, clusters, tracked map[string]string) (map[string]string, map[string]string) {
+	trackedIDs := make(map[string]string)
+	trackedClusters := make(map[string]string)
+	for alias, id := range ids {
+		if tracked[alias] == id {
+			trackedIDs[alias] = id
+			trackedClusters[alias] = clusters[alias]
+		}
+	}
+
+	return trackedIDs, trackedClusters
+}
+
+// trackedReplicationTopologyFlows limits the replication flows of a MirrorMaker service to the
+// ones tracked by a topology
+func trackedReplicationTopologyFlows(flows []aiven.ReplicationFlow, tracked []replicationEdge) []aiven.ReplicationFlow {
+	edges := make(map[replicationEdge]bool)
+	for _, e := range tracked {
+		edges[e] = true
+	}
+
+	var res []aiven.ReplicationFlow
+	for _, f := range flows {
+		if edges[replicationEdge{source: f.SourceCluster, target: f.TargetCluster}] {
+			res = append(res, f)
+		}
+	}
+
+	return res
+}
+
+// kafkaReplicationTopologyFlows returns the existing replication flows between the given aliases
+func kafkaReplicationTopologyFlows(client *aiven.Client, project, serviceName string, aliases map[string]string) ([]aiven.ReplicationFlow, error) {
+	r, err := client.KafkaMirrorMakerReplicationFlow.List(project, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	var flows []aiven.ReplicationFlow
+	for _, f := range r.ReplicationFlows {
+		_, sourceOK := aliases[f.SourceCluster]
+		_, targetOK := aliases[f.TargetCluster]
+		if sourceOK && targetOK {
+			flows = append(flows, f)
+		}
+	}
+
+	return flows, nil
+}
+
+// resourceKafkaReplicationTopologyCreateIntegrations creates the integrations of the given
+// clusters, the ids of the created integrations are added to ids and stored right away so that
+// partially applied changes are tracked
+func resourceKafkaReplicationTopologyCreateIntegrations(
+	ctx context.Context,
+	d *schema.ResourceData,
+	client *aiven.Client,
+	clusters map[string]string,
+	ids map[string]string,
+	timeout time.Duration,
+) error {
+	project := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+
+	for _, alias := range sortedAliases(clusters) {
+		sourceService := clusters[alias]
+		integration, err := client.ServiceIntegrations.Create(
+			project,
+			aiven.CreateServiceIntegrationRequest{
+				DestinationService: &serviceName,
+				IntegrationType:    "kafka_mirrormaker",
+				SourceService:      &sourceService,
+				UserConfig:         map[string]interface{}{"cluster_alias": alias},
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("cannot create kafka_mirrormaker integration for cluster %s: %s", alias, err)
+		}
+
+		ids[alias] = integration.ServiceIntegrationID
+		if err := d.Set("integration_ids", ids); err != nil {
+			return err
+		}
+
+		if err := serviceIntegrationWaitUntilActive(
+			ctx, client, project, integration.ServiceIntegrationID, timeout); err != nil {
+			return fmt.Errorf("unable to wait for cluster %s integration to become active: %s", alias, err)
+		}
+	}
+
+	return nil
+}
+
+func resourceKafkaReplicationTopologyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	project := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	clusters := expandReplicationTopologyClusters(d.Get("cluster").(*schema.Set))
+
+	// the id is set before any integration is created, so that partially created topologies
+	// are tracked by Terraform and cleaned up on destroy
+	d.SetId(buildResourceID(project, serviceName))
+
+	ids := make(map[string]string)
+	if err := resourceKafkaReplicationTopologyCreateIntegrations(ctx, d, client, clusters, ids, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	var created []replicationEdge
+	edges := replicationTopologyEdges(sortedAliases(clusters), expandReplicationTopologyEdges(d.Get("flow").(*schema.Set)))
+	for _, e := range edges {
+		if err := client.KafkaMirrorMakerReplicationFlow.Create(project, serviceName, kafkaReplicationTopologyFlowRequest(d, e)); err != nil {
+			return diag.Errorf("cannot create replication flow %s: %s", e, err)
+		}
+
+		created = append(created, e)
+		if err := d.Set("replication_flows", flattenReplicationTopologyEdges(created)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceKafkaReplicationTopologyRead(ctx, d, m)
+}
+
+func resourceKafkaReplicationTopologyRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return diag.FromErr(readKafkaReplicationTopology(d, m.(*providerMeta).client, false))
+}
+
+// readKafkaReplicationTopology reads the integrations and flows tracked by a topology, all
+// integrations of the MirrorMaker service and the flows between them are adopted on import
+func readKafkaReplicationTopology(d *schema.ResourceData, client *aiven.Client, adopt bool) error {
+	project, serviceName := splitResourceID2(d.Id())
+	ids, clusters, err := kafkaReplicationTopologyIntegrations(client, project, serviceName)
+	if err != nil {
+		return resourceReadHandleNotFound(err, d)
+	}
+
+	if !adopt {
+		ids, clusters = trackedReplicationTopologyIntegrations(ids, clusters,
+			expandReplicationTopologyIDs(d.Get("integration_ids").(map[string]interface{})))
+	}
+	if len(ids) == 0 {
+		if adopt {
+			return fmt.Errorf("service %s has no kafka_mirrormaker integrations", serviceName)
+		}
+
+		log.Printf("[DEBUG] no integrations of %s are left, removing it from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	flows, err := kafkaReplicationTopologyFlows(client, project, serviceName, clusters)
+	if err != nil {
+		return resourceReadHandleNotFound(err, d)
+	}
+	if !adopt {
+		flows = trackedReplicationTopologyFlows(flows, expandReplicationTopologyEdges(d.Get("replication_flows").(*schema.Set)))
+	}
+
+	if err := d.Set("project", project); err != nil {
+		return err
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return err
+	}
+
+	var tfClusters []map[string]interface{}
+	for _, alias := range sortedAliases(clusters) {
+		tfClusters = append(tfClusters, map[string]interface{}{
+			"alias":        alias,
+			"service_name": clusters[alias],
+		})
+	}
+	if err := d.Set("cluster", tfClusters); err != nil {
+		return err
+	}
+	if err := d.Set("integration_ids", ids); err != nil {
+		return err
+	}
+
+	var edges []replicationEdge
+	for _, f := range flows {
+		edges = append(edges, replicationEdge{source: f.SourceCluster, target: f.TargetCluster})
+	}
+	edges = replicationTopologyEdges(nil, edges)
+	if err := d.Set("replication_flows", flattenReplicationTopologyEdges(edges)); err != nil {
+		return err
+	}
+
+	configured := replicationTopologyConfiguredEdges(sortedAliases(clusters), edges, d.Get("flow").(*schema.Set).Len() > 0)
+	if err := d.Set("flow", flattenReplicationTopologyEdges(configured)); err != nil {
+		return err
+	}
+
+	// all flows of the topology share the same settings, so any of them describes the topology
+	if len(flows) > 0 {
+		f := flows[0]
+		if err := d.Set("enable", f.Enabled); err != nil {
+			return err
+		}
+		if err := d.Set("topics", f.Topics); err != nil {
+			return err
+		}
+		if err := d.Set("topics_blacklist", f.TopicsBlacklist); err != nil {
+			return err
+		}
+		if err := d.Set("replication_policy_class", f.ReplicationPolicyClass); err != nil {
+			return err
+		}
+		if err := d.Set("sync_group_offsets_enabled", f.SyncGroupOffsetsEnabled); err != nil {
+			return err
+		}
+		if err := d.Set("sync_group_offsets_interval_seconds", f.SyncGroupOffsetsIntervalSeconds); err != nil {
+			return err
+		}
+		if err := d.Set("emit_heartbeats_enabled", f.EmitHeartbeatsEnabled); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resourceKafkaReplicationTopologyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	project, serviceName := splitResourceID2(d.Id())
+
+	// only the integrations and flows tracked in the state are changed
+	oldClusters, _ := d.GetChange("cluster")
+	current := expandReplicationTopologyClusters(oldClusters.(*schema.Set))
+	oldIDs, _ := d.GetChange("integration_ids")
+	ids := expandReplicationTopologyIDs(oldIDs.(map[string]interface{}))
+	oldFlows, _ := d.GetChange("replication_flows")
+	tracked := replicationTopologyEdges(nil, expandReplicationTopologyEdges(oldFlows.(*schema.Set)))
+
+	clusters := expandReplicationTopologyClusters(d.Get("cluster").(*schema.Set))
+	edges := replicationTopologyEdges(sortedAliases(clusters), expandReplicationTopologyEdges(d.Get("flow").(*schema.Set)))
+	wanted := make(map[replicationEdge]bool)
+	for _, e := range edges {
+		wanted[e] = true
+	}
+
+	// flows have to be removed before the integrations of the clusters they refer to
+	var kept []replicationEdge
+	existing := make(map[replicationEdge]bool)
+	for _, e := range tracked {
+		if wanted[e] && clusters[e.source] == current[e.source] && clusters[e.target] == current[e.target] {
+			kept = append(kept, e)
+			existing[e] = true
+			continue
+		}
+
+		log.Printf("[DEBUG] deleting replication flow %s of %s", e, d.Id())
+		if err := client.KafkaMirrorMakerReplicationFlow.Delete(project, serviceName, e.source, e.target); err != nil && !aiven.IsNotFound(err) {
+			return diag.Errorf("cannot delete replication flow %s: %s", e, err)
+		}
+	}
+	if err := d.Set("replication_flows", flattenReplicationTopologyEdges(kept)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, alias := range sortedAliases(current) {
+		if clusters[alias] == current[alias] {
+			continue
+		}
+
+		if id, ok := ids[alias]; ok {
+			log.Printf("[DEBUG] deleting kafka_mirrormaker integration of cluster %s of %s", alias, d.Id())
+			if err := client.ServiceIntegrations.Delete(project, id); err != nil && !aiven.IsNotFound(err) {
+				return diag.Errorf("cannot delete kafka_mirrormaker integration of cluster %s: %s", alias, err)
+			}
+		}
+
+		delete(ids, alias)
+		if err := d.Set("integration_ids", ids); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	added := make(map[string]string)
+	for alias, sourceService := range clusters {
+		if current[alias] != sourceService {
+			added[alias] = sourceService
+		}
+	}
+
+	if err := resourceKafkaReplicationTopologyCreateIntegrations(ctx, d, client, added, ids, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, e := range edges {
+		if existing[e] {
+			if _, err := client.KafkaMirrorMakerReplicationFlow.Update(
+				project, serviceName, e.source, e.target, kafkaReplicationTopologyFlowRequest(d, e)); err != nil {
+				return diag.Errorf("cannot update replication flow %s: %s", e, err)
+			}
+			continue
+		}
+
+		if err := client.KafkaMirrorMakerReplicationFlow.Create(project, serviceName, kafkaReplicationTopologyFlowRequest(d, e)); err != nil {
+			return diag.Errorf("cannot create replication flow %s: %s", e, err)
+		}
+
+		kept = append(kept, e)
+		if err := d.Set("replication_flows", flattenReplicationTopologyEdges(kept)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceKafkaReplicationTopologyRead(ctx, d, m)
+}
+
+func resourceKafkaReplicationTopologyDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	// only the integrations and flows tracked in the state are deleted, the service can have
+	// integrations and flows managed by other resources
+	project, serviceName := splitResourceID2(d.Id())
+	for _, e := range replicationTopologyEdges(nil, expandReplicationTopologyEdges(d.Get("replication_flows").(*schema.Set))) {
+		err := client.KafkaMirrorMakerReplicationFlow.Delete(project, serviceName, e.source, e.target)
+		if err != nil && !aiven.IsNotFound(err) {
+			return diag.Errorf("cannot delete replication flow %s: %s", e, err)
+		}
+	}
+
+	ids := expandReplicationTopologyIDs(d.Get("integration_ids").(map[string]interface{}))
+	for _, alias := range sortedAliases(ids) {
+		if err := client.ServiceIntegrations.Delete(project, ids[alias]); err != nil && !aiven.IsNotFound(err) {
+			return diag.Errorf("cannot delete kafka_mirrormaker integration of cluster %s: %s", alias, err)
+		}
+	}
+
+	return nil
+}
+
+func resourceKafkaReplicationTopologyState(_ context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if len(strings.Split(d.Id(), "/")) != 2 {
+		return nil, fmt.Errorf("invalid identifier %v, expected <project_name>/<service_name>", d.Id())
+	}
+
+	if err := readKafkaReplicationTopology(d, m.(*providerMeta).client, true); err != nil {
+		return nil, fmt.Errorf("cannot get kafka replication topology: %s", err)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
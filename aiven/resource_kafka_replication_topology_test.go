@@ -0,0 +1,298 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccAivenKafkaReplicationTopology_basic(t *testing.T) {
+	resourceName := "aiven_kafka_replication_topology.foo"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenKafkaReplicationTopologyResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKafkaReplicationTopologyResource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "project", os.Getenv("AIVEN_PROJECT_NAME")),
+					resource.TestCheckResourceAttr(resourceName, "service_name", fmt.Sprintf("test-acc-rt-mm-%s", rName)),
+					resource.TestCheckResourceAttr(resourceName, "cluster.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "replication_flows.#", "6"),
+					resource.TestCheckResourceAttr(resourceName, "integration_ids.%", "3"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckAivenKafkaReplicationTopologyResourceDestroy(s *terraform.State) error {
+	c := testAccProvider.Meta().(*providerMeta).client
+
+	// loop through the resources in state, verifying each replication topology is destroyed
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aiven_kafka_replication_topology" {
+			continue
+		}
+
+		project, serviceName := splitResourceID2(rs.Primary.ID)
+		ids, _, err := kafkaReplicationTopologyIntegrations(c, project, serviceName)
+		if err != nil {
+			if aiven.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		if len(ids) > 0 {
+			return fmt.Errorf("kafka replication topology integrations still exist, id %s", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccKafkaReplicationTopologyResource(name string) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_kafka" "kafka" {
+			count = 3
+
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "business-4"
+			service_name = "test-acc-rt-${count.index}-%s"
+		}
+
+		resource "aiven_kafka_mirrormaker" "mm" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-4"
+			service_name = "test-acc-rt-mm-%s"
+		}
+
+		resource "aiven_kafka_replication_topology" "foo" {
+			project = data.aiven_project.foo.project
+			service_name = aiven_kafka_mirrormaker.mm.service_name
+
+			dynamic "cluster" {
+				for_each = aiven_kafka.kafka
+
+				content {
+					alias = "cluster-${cluster.key}"
+					service_name = cluster.value.service_name
+				}
+			}
+
+			topics = [".*"]
+			topics_blacklist = [
+				".*[\\-\\.]internal",
+				".*\\.replica",
+				"__.*"
+			]
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name, name)
+}
+
+func Test_replicationTopologyEdges(t *testing.T) {
+	tests := []struct {
+		name     string
+		aliases  []string
+		explicit []replicationEdge
+		want     []replicationEdge
+	}{
+		{
+			"full-mesh",
+			[]string{"a", "b", "c"},
+			nil,
+			[]replicationEdge{
+				{source: "a", target: "b"},
+				{source: "a", target: "c"},
+				{source: "b", target: "a"},
+				{source: "b", target: "c"},
+				{source: "c", target: "a"},
+				{source: "c", target: "b"},
+			},
+		},
+		{
+			"explicit",
+			[]string{"a", "b", "c"},
+			[]replicationEdge{
+				{source: "c", target: "a"},
+				{source: "a", target: "b"},
+			},
+			[]replicationEdge{
+				{source: "a", target: "b"},
+				{source: "c", target: "a"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := replicationTopologyEdges(tt.aliases, tt.explicit); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("replicationTopologyEdges() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_replicationTopologyCycle(t *testing.T) {
+	tests := []struct {
+		name  string
+		edges []replicationEdge
+		want  []string
+	}{
+		{
+			"no-flows",
+			nil,
+			nil,
+		},
+		{
+			"chain",
+			[]replicationEdge{
+				{source: "a", target: "b"},
+				{source: "b", target: "c"},
+				{source: "a", target: "c"},
+			},
+			nil,
+		},
+		{
+			"bidirectional",
+			[]replicationEdge{
+				{source: "a", target: "b"},
+				{source: "b", target: "a"},
+			},
+			[]string{"a", "b", "a"},
+		},
+		{
+			"ring",
+			[]replicationEdge{
+				{source: "x", target: "a"},
+				{source: "a", target: "b"},
+				{source: "b", target: "c"},
+				{source: "c", target: "a"},
+			},
+			[]string{"a", "b", "c", "a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := replicationTopologyCycle(tt.edges); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("replicationTopologyCycle() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_replicationTopologyConfiguredEdges(t *testing.T) {
+	aliases := []string{"a", "b"}
+	mesh := []replicationEdge{{source: "a", target: "b"}, {source: "b", target: "a"}}
+	oneWay := []replicationEdge{{source: "a", target: "b"}}
+
+	tests := []struct {
+		name       string
+		edges      []replicationEdge
+		configured bool
+		want       []replicationEdge
+	}{
+		{"full-mesh-not-configured", mesh, false, nil},
+		{"full-mesh-configured", mesh, true, mesh},
+		{"explicit-not-configured", oneWay, false, oneWay},
+		{"explicit-configured", oneWay, true, oneWay},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := replicationTopologyConfiguredEdges(aliases, tt.edges, tt.configured); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("replicationTopologyConfiguredEdges() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_trackedReplicationTopologyIntegrations(t *testing.T) {
+	ids := map[string]string{"a": "id-a", "b": "id-b", "other": "id-other"}
+	clusters := map[string]string{"a": "kafka-a", "b": "kafka-b", "other": "kafka-other"}
+
+	tests := []struct {
+		name         string
+		tracked      map[string]string
+		wantIDs      map[string]string
+		wantClusters map[string]string
+	}{
+		{
+			"tracked",
+			map[string]string{"a": "id-a", "b": "id-b"},
+			map[string]string{"a": "id-a", "b": "id-b"},
+			map[string]string{"a": "kafka-a", "b": "kafka-b"},
+		},
+		{
+			"replaced",
+			map[string]string{"a": "id-a", "b": "id-old"},
+			map[string]string{"a": "id-a"},
+			map[string]string{"a": "kafka-a"},
+		},
+		{
+			"deleted",
+			map[string]string{"a": "id-a", "c": "id-c"},
+			map[string]string{"a": "id-a"},
+			map[string]string{"a": "kafka-a"},
+		},
+		{
+			"none",
+			map[string]string{},
+			map[string]string{},
+			map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotIDs, gotClusters := trackedReplicationTopologyIntegrations(ids, clusters, tt.tracked)
+			if !reflect.DeepEqual(gotIDs, tt.wantIDs) || !reflect.DeepEqual(gotClusters, tt.wantClusters) {
+				t.Errorf("trackedReplicationTopologyIntegrations() = %v, %v, want %v, %v", gotIDs, gotClusters, tt.wantIDs, tt.wantClusters)
+			}
+		})
+	}
+}
+
+func Test_trackedReplicationTopologyFlows(t *testing.T) {
+	flows := []aiven.ReplicationFlow{
+		{SourceCluster: "a", TargetCluster: "b"},
+		{SourceCluster: "b", TargetCluster: "a"},
+	}
+
+	tests := []struct {
+		name    string
+		tracked []replicationEdge
+		want    []aiven.ReplicationFlow
+	}{
+		{"all", []replicationEdge{{source: "a", target: "b"}, {source: "b", target: "a"}}, flows},
+		{"one", []replicationEdge{{source: "b", target: "a"}}, []aiven.ReplicationFlow{{SourceCluster: "b", TargetCluster: "a"}}},
+		{"deleted", []replicationEdge{{source: "a", target: "c"}}, nil},
+		{"none", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := trackedReplicationTopologyFlows(flows, tt.tracked); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("trackedReplicationTopologyFlows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -288,7 +288,7 @@ func resourceKafkaTopicCreate(ctx context.Context, d *schema.ResourceData, m int
 	}
 
 	w := &KafkaTopicCreateWaiter{
-		Client:        m.(*aiven.Client),
+		Client:        m.(*providerMeta).client,
 		Project:       project,
 		ServiceName:   serviceName,
 		CreateRequest: createRequest,
@@ -368,7 +368,7 @@ func resourceKafkaTopicRead(ctx context.Context, d *schema.ResourceData, m inter
 	project, serviceName, topicName := splitResourceID3(d.Id())
 	topic, err := getTopic(ctx, d, m, false)
 	if err != nil {
-		return diag.FromErr(resourceReadHandleNotFound(err, d))
+		return diag.FromErr(resourceReadHandleServicePoweredOff(err, d, m.(*providerMeta).client, project, serviceName))
 	}
 
 	if err := d.Set("project", project); err != nil {
@@ -445,7 +445,7 @@ func getTopic(ctx context.Context, d *schema.ResourceData, m interface{}, ignore
 	project, serviceName, topicName := splitResourceID3(d.Id())
 
 	w := &KafkaTopicAvailabilityWaiter{
-		Client:      m.(*aiven.Client),
+		Client:      m.(*providerMeta).client,
 		Project:     project,
 		ServiceName: serviceName,
 		TopicName:   topicName,
@@ -462,7 +462,7 @@ func getTopic(ctx context.Context, d *schema.ResourceData, m interface{}, ignore
 }
 
 func resourceKafkaTopicUpdate(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	partitions := d.Get("partitions").(int)
 	projectName, serviceName, topicName := splitResourceID3(d.Id())
@@ -488,7 +488,7 @@ func resourceKafkaTopicUpdate(_ context.Context, d *schema.ResourceData, m inter
 }
 
 func resourceKafkaTopicDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, serviceName, topicName := splitResourceID3(d.Id())
 
@@ -349,7 +349,7 @@ func testAccCheckAivenKafkaTopicAttributes(n string) resource.TestCheckFunc {
 }
 
 func testAccCheckAivenKafkaTopicResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each kafka topic is destroyed
 	for _, rs := range s.RootModule().Resources {
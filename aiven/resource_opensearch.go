@@ -8,7 +8,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/aiven/aiven-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -41,6 +40,7 @@ func resourceOpensearch() *schema.Resource {
 		ReadContext:   resourceServiceRead,
 		UpdateContext: resourceServiceUpdate,
 		DeleteContext: resourceServiceDelete,
+		CustomizeDiff: resourceServiceCustomizeDiffWrapper(ServiceTypeOpensearch),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceElasticsearchState,
 		},
@@ -54,7 +54,7 @@ func resourceOpensearch() *schema.Resource {
 }
 
 func resourceElasticsearchState(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	if len(strings.Split(d.Id(), "/")) != 2 {
 		return nil, fmt.Errorf("invalid identifier %v, expected <project_name>/<service_name>", d.Id())
@@ -34,6 +34,7 @@ func TestAccAiven_pg(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "maintenance_window_time", "10:00:00"),
 					resource.TestCheckResourceAttr(resourceName, "state", "RUNNING"),
 					resource.TestCheckResourceAttr(resourceName, "termination_protection", "false"),
+					resource.TestCheckResourceAttrSet(resourceName, "cost_estimate.0.monthly_price_usd"),
 				),
 			},
 		},
@@ -11,6 +11,7 @@ import (
 
 	"github.com/aiven/aiven-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -86,6 +87,10 @@ var aivenProjectSchema = map[string]*schema.Schema{
 		Description:      complex("The id of the billing group that is linked to this project.").referenced().build(),
 		DiffSuppressFunc: emptyObjectDiffSuppressFunc,
 	},
+	"tag":              projectTagSchema(),
+	"clone":            projectCloneSchema(),
+	"cloned":           projectClonedSchema(),
+	"lost_team_grants": projectLostTeamGrantsSchema(),
 	// deprecated fields
 	"vat_id": {
 		Type:             schema.TypeString,
@@ -142,7 +147,14 @@ var aivenProjectSchema = map[string]*schema.Schema{
 
 func resourceProject() *schema.Resource {
 	return &schema.Resource{
-		Description:   "The Project resource allows the creation and management of Aiven Projects.",
+		Description: `
+The Project resource allows the creation and management of Aiven Projects.
+
+Changing ` + "`account_id`" + ` or ` + "`billing_group`" + ` moves the project in place. The move is checked
+when it is planned: the user must be an owner of both accounts, the billing group must belong to
+the account of the project, and a project with an unbilled balance cannot change its billing
+currency. The account team grants removed by the move are listed in ` + "`lost_team_grants`" + `.
+`,
 		CreateContext: resourceProjectCreate,
 		ReadContext:   resourceProjectRead,
 		UpdateContext: resourceProjectUpdate,
@@ -150,13 +162,17 @@ func resourceProject() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceProjectState,
 		},
+		CustomizeDiff: customdiff.Sequence(
+			resourceProjectTagsCustomizeDiff,
+			resourceProjectCustomizeDiff,
+		),
 
 		Schema: aivenProjectSchema,
 	}
 }
 
 func resourceProjectCreate(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 	cardID, err := getLongCardID(client, d.Get("card_id").(string))
 	if err != nil {
 		return diag.Errorf("Error getting long card id: %s", err)
@@ -187,14 +203,28 @@ func resourceProjectCreate(_ context.Context, d *schema.ResourceData, m interfac
 	}
 
 	projectName := d.Get("project").(string)
-	_, err = client.Projects.Create(
+	sourceProjectName := d.Get("copy_from_project").(string)
+	cloneOpts := getProjectCloneOptions(d)
+	if cloneOpts != nil {
+		sourceProjectName = cloneOpts.SourceProject
+	}
+
+	var sourceProject *aiven.Project
+	if sourceProjectName != "" {
+		sourceProject, err = client.Projects.Get(sourceProjectName)
+		if err != nil {
+			return append(diags, diag.Errorf("cannot get source project %s: %s", sourceProjectName, err)...)
+		}
+	}
+
+	project, err := client.Projects.Create(
 		aiven.CreateProjectRequest{
 			BillingAddress:               billingAddress,
 			BillingEmails:                contactEmailListForAPI(d, "billing_emails", true),
 			BillingExtraText:             billingExtraText,
 			CardID:                       cardID,
 			Cloud:                        optionalStringPointer(d, "default_cloud"),
-			CopyFromProject:              d.Get("copy_from_project").(string),
+			CopyFromProject:              sourceProjectName,
 			CountryCode:                  countryCode,
 			Project:                      projectName,
 			TechnicalEmails:              contactEmailListForAPI(d, "technical_emails", true),
@@ -208,50 +238,53 @@ func resourceProjectCreate(_ context.Context, d *schema.ResourceData, m interfac
 		return append(diags, diag.FromErr(err)...)
 	}
 
+	d.SetId(projectName)
+
 	if billingGroupID, ok := d.GetOk("billing_group"); ok {
 		dia := resourceProjectAssignToBillingGroup(projectName, billingGroupID.(string), client, d)
 		if dia.HasError() {
 			return append(diags, dia...)
 		}
-	} else {
-		// if billing_group is not set but copy_from_project is not empty,
+	} else if sourceProject != nil && (cloneOpts == nil || cloneOpts.BillingGroup) {
+		// if billing_group is not set but there is a source project,
 		// copy billing group from source project
-		if sourceProject, ok := d.GetOk("copy_from_project"); ok {
-			dia := resourceProjectCopyBillingGroupFromProject(client, sourceProject.(string), d)
-			if dia.HasError() {
-				return append(diags, dia...)
-			}
+		dia := resourceProjectCopyBillingGroupFromProject(client, sourceProject, d)
+		if dia.HasError() {
+			return append(diags, dia...)
 		}
 	}
 
-	d.SetId(projectName)
-
-	return append(diags, resourceProjectGetCACert(projectName, client, d)...)
-}
-
-func resourceProjectCopyBillingGroupFromProject(
-	client *aiven.Client, sourceProjectName string, d *schema.ResourceData) diag.Diagnostics {
-	list, err := client.BillingGroup.ListAll()
-	if err != nil {
-		return diag.FromErr(err)
+	if tags := d.Get("tag").(*schema.Set); tags.Len() > 0 {
+		if err := setProjectTags(client, projectName, tags); err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
 	}
 
-	for _, bg := range list {
-		projects, err := client.BillingGroup.GetProjects(bg.Id)
+	if cloneOpts != nil {
+		result, err := cloneProject(client, sourceProject, project, *cloneOpts)
 		if err != nil {
-			return diag.FromErr(err)
+			return append(diags, diag.Errorf("cannot clone project %s: %s", sourceProjectName, err)...)
 		}
-
-		for _, pr := range projects {
-			if pr == sourceProjectName {
-				log.Printf("[DEBUG] Source project `%s` has billing group `%s`", sourceProjectName, bg.Id)
-				return resourceProjectAssignToBillingGroup(sourceProjectName, bg.Id, client, d)
-			}
+		if cloneOpts.BillingGroup {
+			result.BillingGroup = d.Get("billing_group").(string)
+		}
+		if err := d.Set("cloned", flattenProjectCloneResult(result)); err != nil {
+			return append(diags, diag.FromErr(err)...)
 		}
 	}
 
-	log.Printf("[DEBUG] Source project `%s` is not associated to any billing group", sourceProjectName)
-	return nil
+	return append(diags, resourceProjectGetCACert(projectName, client, d)...)
+}
+
+func resourceProjectCopyBillingGroupFromProject(
+	client *aiven.Client, sourceProject *aiven.Project, d *schema.ResourceData) diag.Diagnostics {
+	if sourceProject.BillingGroupId == "" {
+		log.Printf("[DEBUG] Source project `%s` is not associated to any billing group", sourceProject.Name)
+		return nil
+	}
+
+	log.Printf("[DEBUG] Source project `%s` has billing group `%s`", sourceProject.Name, sourceProject.BillingGroupId)
+	return resourceProjectAssignToBillingGroup(d.Get("project").(string), sourceProject.BillingGroupId, client, d)
 }
 
 func resourceProjectAssignToBillingGroup(
@@ -288,7 +321,7 @@ func resourceProjectAssignToBillingGroup(
 }
 
 func resourceProjectRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project, err := client.Projects.Get(d.Id())
 	if err != nil {
@@ -340,58 +373,67 @@ func resourceProjectRead(_ context.Context, d *schema.ResourceData, m interface{
 }
 
 func resourceProjectUpdate(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	cardID, err := getLongCardID(client, d.Get("card_id").(string))
 	if err != nil {
 		return diag.Errorf("Error getting long card id: %s", err)
 	}
 
-	var project *aiven.Project
-	projectName := d.Get("project").(string)
-	if billingGroupID, ok := d.GetOk("billing_group"); ok {
-		project, err = client.Projects.Update(
-			d.Id(),
-			aiven.UpdateProjectRequest{
-				Name:             projectName,
-				BillingAddress:   optionalStringPointerForUndefined(d, "billing_address"),
-				BillingEmails:    contactEmailListForAPI(d, "billing_emails", false),
-				BillingExtraText: optionalStringPointerForUndefined(d, "billing_extra_text"),
-				CardID:           cardID,
-				Cloud:            optionalStringPointer(d, "default_cloud"),
-				CountryCode:      optionalStringPointerForUndefined(d, "country_code"),
-				TechnicalEmails:  contactEmailListForAPI(d, "technical_emails", false),
-				AccountId:        optionalStringPointer(d, "account_id"),
-				BillingCurrency:  d.Get("billing_currency").(string),
-				VatID:            optionalStringPointerForUndefined(d, "vat_id"),
-			},
-		)
-		if err != nil {
+	// the billing details of a project in a billing group are only sent when they are set,
+	// otherwise they would overwrite the properties of the billing group
+	billingGroupID, inBillingGroup := d.GetOk("billing_group")
+	stringPointer := optionalStringPointer
+	if inBillingGroup {
+		stringPointer = optionalStringPointerForUndefined
+	}
+
+	// the teams of the old account lose their access when the project moves to another account,
+	// the lost grants are planned unless the new account is created in the same apply
+	if oldAccount, _ := d.GetChange("account_id"); d.HasChange("account_id") && len(d.Get("lost_team_grants").([]interface{})) == 0 {
+		grants := []projectTeamGrant{}
+		if oldAccount.(string) != "" {
+			if grants, err = getProjectTeamGrants(client, oldAccount.(string), d.Id()); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		if err := d.Set("lost_team_grants", flattenProjectTeamGrants(grants)); err != nil {
 			return diag.FromErr(err)
 		}
+	}
 
-		dia := resourceProjectAssignToBillingGroup(d.Get("project").(string), billingGroupID.(string), client, d)
+	projectName := d.Get("project").(string)
+	project, err := client.Projects.Update(
+		d.Id(),
+		aiven.UpdateProjectRequest{
+			Name:             projectName,
+			BillingAddress:   stringPointer(d, "billing_address"),
+			BillingEmails:    contactEmailListForAPI(d, "billing_emails", false),
+			BillingExtraText: stringPointer(d, "billing_extra_text"),
+			CardID:           cardID,
+			Cloud:            optionalStringPointer(d, "default_cloud"),
+			CountryCode:      stringPointer(d, "country_code"),
+			TechnicalEmails:  contactEmailListForAPI(d, "technical_emails", false),
+			AccountId:        optionalStringPointer(d, "account_id"),
+			BillingCurrency:  d.Get("billing_currency").(string),
+			VatID:            stringPointer(d, "vat_id"),
+		},
+	)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// the project is moved to the billing group after the account, the billing group can belong
+	// to the new account
+	if inBillingGroup {
+		dia := resourceProjectAssignToBillingGroup(projectName, billingGroupID.(string), client, d)
 		if dia.HasError() {
 			return dia
 		}
-	} else {
-		project, err = client.Projects.Update(
-			d.Id(),
-			aiven.UpdateProjectRequest{
-				Name:             projectName,
-				BillingAddress:   optionalStringPointer(d, "billing_address"),
-				BillingEmails:    contactEmailListForAPI(d, "billing_emails", false),
-				BillingExtraText: optionalStringPointer(d, "billing_extra_text"),
-				CardID:           cardID,
-				Cloud:            optionalStringPointer(d, "default_cloud"),
-				CountryCode:      optionalStringPointer(d, "country_code"),
-				TechnicalEmails:  contactEmailListForAPI(d, "technical_emails", false),
-				AccountId:        optionalStringPointer(d, "account_id"),
-				BillingCurrency:  d.Get("billing_currency").(string),
-				VatID:            optionalStringPointer(d, "vat_id"),
-			},
-		)
-		if err != nil {
+	}
+
+	if d.HasChange("tag") {
+		if err := setProjectTags(client, project.Name, d.Get("tag").(*schema.Set)); err != nil {
 			return diag.FromErr(err)
 		}
 	}
@@ -402,7 +444,7 @@ func resourceProjectUpdate(_ context.Context, d *schema.ResourceData, m interfac
 }
 
 func resourceProjectDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	err := client.Projects.Delete(d.Id())
 
@@ -427,7 +469,7 @@ func resourceProjectDelete(_ context.Context, d *schema.ResourceData, m interfac
 }
 
 func resourceProjectState(_ context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project, err := client.Projects.Get(d.Id())
 	if err != nil {
@@ -478,7 +520,8 @@ func contactEmailListForAPI(d *schema.ResourceData, field string, newResource bo
 	// should be sent if user has explicitly defined that even when copy_from_project
 	// is set but Terraform does not support checking that; d.GetOkExists returns false
 	// even if the value is set (to empty).
-	if _, ok := d.GetOk("copy_from_project"); ok || !newResource {
+	_, copied := d.GetOk("copy_from_project")
+	if copied || getProjectCloneOptions(d) != nil || !newResource {
 		results = []*aiven.ContactEmail{}
 	}
 	valuesInterface, ok := d.GetOk(field)
@@ -558,5 +601,13 @@ func setProjectTerraformProperties(d *schema.ResourceData, client *aiven.Client,
 		return diag.FromErr(err)
 	}
 
+	tags, err := getProjectTags(client, project.Name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("tag", flattenProjectTags(tags)); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return nil
 }
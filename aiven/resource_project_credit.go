@@ -0,0 +1,176 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// projectCredit is a credit claimed by a project, the credits are not covered by aiven-go-client
+type projectCredit struct {
+	Code           string  `json:"code"`
+	Type           string  `json:"type"`
+	Value          string  `json:"value"`
+	RemainingValue string  `json:"remaining_value"`
+	ExpireTime     *string `json:"expire_time"`
+}
+
+var aivenProjectCreditSchema = map[string]*schema.Schema{
+	"project": commonSchemaProjectReference,
+	"code": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: complex("Credit code to claim for the project.").forceNew().build(),
+	},
+	"type": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Type of the credit, for example `discount` or `trial`",
+	},
+	"value": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Original value of the credit",
+	},
+	"remaining_value": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Value of the credit that is not used yet",
+	},
+	"expire_time": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Time the credit expires at, empty for credits that do not expire",
+	},
+}
+
+func resourceProjectCredit() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+The Project Credit resource claims a credit code for a project.
+
+Claimed credits cannot be removed from a project, so destroying the resource only removes it from
+the Terraform state.
+`,
+		CreateContext: resourceProjectCreditCreate,
+		ReadContext:   resourceProjectCreditRead,
+		DeleteContext: resourceProjectCreditDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceProjectCreditState,
+		},
+
+		Schema: aivenProjectCreditSchema,
+	}
+}
+
+func resourceProjectCreditCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	projectName := d.Get("project").(string)
+	code := d.Get("code").(string)
+
+	path := clientRequestPath("project", projectName, "credits")
+	if err := clientRequest(client, http.MethodPost, path, map[string]string{"code": code}, nil); err != nil {
+		return diag.Errorf("cannot claim credit code for project %s: %s", projectName, err)
+	}
+
+	d.SetId(buildResourceID(projectName, code))
+
+	return resourceProjectCreditRead(ctx, d, m)
+}
+
+func resourceProjectCreditRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	projectName, code := splitResourceID2(d.Id())
+	credit, err := getProjectCredit(client, projectName, code)
+	if err != nil {
+		if !aiven.IsNotFound(err) || d.Get("type").(string) == "" {
+			return diag.FromErr(resourceReadHandleNotFound(err, d))
+		}
+
+		// a claimed credit cannot be claimed again, so a credit that is no longer listed is
+		// used up rather than gone
+		log.Printf("[DEBUG] credit of project %s is no longer listed, it is used up", projectName)
+		if err := d.Set("remaining_value", "0"); err != nil {
+			return diag.FromErr(err)
+		}
+		return nil
+	}
+
+	if err := d.Set("project", projectName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("code", credit.Code); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("type", credit.Type); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("value", credit.Value); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("remaining_value", credit.RemainingValue); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("expire_time", stringValue(credit.ExpireTime)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceProjectCreditDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] claimed credits cannot be removed, removing credit %s from the state only", d.Id())
+
+	return nil
+}
+
+func resourceProjectCreditState(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if len(strings.Split(d.Id(), "/")) != 2 {
+		return nil, fmt.Errorf("invalid identifier %v, expected <project_name>/<code>", d.Id())
+	}
+
+	di := resourceProjectCreditRead(ctx, d, m)
+	if di.HasError() {
+		return nil, fmt.Errorf("cannot get project credit: %v", di)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// getProjectCredit returns a credit claimed by a project by its code
+func getProjectCredit(client *aiven.Client, projectName, code string) (*projectCredit, error) {
+	var r struct {
+		Credits []projectCredit `json:"credits"`
+	}
+
+	if err := clientRequest(client, http.MethodGet, clientRequestPath("project", projectName, "credits"), nil, &r); err != nil {
+		return nil, err
+	}
+
+	if c := findProjectCredit(r.Credits, code); c != nil {
+		return c, nil
+	}
+
+	return nil, aiven.Error{Status: 404, Message: fmt.Sprintf("credit of project %s not found", projectName)}
+}
+
+// findProjectCredit returns the credit with the given code or nil
+func findProjectCredit(credits []projectCredit, code string) *projectCredit {
+	for i := range credits {
+		if credits[i].Code == code {
+			return &credits[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAivenProjectCredit_basic(t *testing.T) {
+	if os.Getenv("AIVEN_CREDIT_CODE") == "" {
+		t.Skip("AIVEN_CREDIT_CODE env variable is required to run this test")
+	}
+
+	resourceName := "aiven_project_credit.foo"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenProjectResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProjectCreditResource(rName, os.Getenv("AIVEN_CREDIT_CODE")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "code", os.Getenv("AIVEN_CREDIT_CODE")),
+					resource.TestCheckResourceAttrSet(resourceName, "value"),
+					resource.TestCheckResourceAttrSet(resourceName, "remaining_value"),
+				),
+			},
+		},
+	})
+}
+
+func testAccProjectCreditResource(name, code string) string {
+	return fmt.Sprintf(`
+		resource "aiven_project" "foo" {
+			project = "test-acc-pr-%s"
+			default_cloud = "aws-eu-west-2"
+			billing_currency = "EUR"
+		}
+
+		resource "aiven_project_credit" "foo" {
+			project = aiven_project.foo.project
+			code = "%s"
+		}
+		`, name, code)
+}
+
+func Test_findProjectCredit(t *testing.T) {
+	credits := []projectCredit{
+		{Code: "WELCOME", RemainingValue: "120.50"},
+		{Code: "SPRING", RemainingValue: "0.00"},
+	}
+
+	tests := []struct {
+		name string
+		code string
+		want string
+	}{
+		{"first", "WELCOME", "120.50"},
+		{"second", "SPRING", "0.00"},
+		{"unknown", "OTHER", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := findProjectCredit(credits, tt.code)
+			if (got == nil) != (tt.want == "") || (got != nil && got.RemainingValue != tt.want) {
+				t.Errorf("findProjectCredit() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ package aiven
 import (
 	"fmt"
 	"log"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -122,6 +123,292 @@ func TestAccAivenProject_accounts(t *testing.T) {
 	})
 }
 
+func TestAccAivenProject_tags(t *testing.T) {
+	resourceName := "aiven_project.foo"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenProjectResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProjectResourceTags(rName, "data"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tag.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "tag.*", map[string]string{
+						"key":   "team",
+						"value": "data",
+					}),
+					resource.TestCheckResourceAttr("data.aiven_project.project", "tag.#", "2"),
+				),
+			},
+			{
+				Config: testAccProjectResourceTags(rName, "platform"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tag.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "tag.*", map[string]string{
+						"key":   "team",
+						"value": "platform",
+					}),
+				),
+			},
+			{
+				Config:      testAccProjectResourceTags(rName, "platform") + testAccProjectResourceDuplicateTag(rName),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`tag "team" is set more than once`),
+			},
+		},
+	})
+}
+
+func testAccProjectResourceDuplicateTag(name string) string {
+	return fmt.Sprintf(`
+		resource "aiven_project" "bar" {
+			project = "test-acc-pr-dup-%s"
+			default_cloud = "aws-eu-west-2"
+			billing_currency = "EUR"
+
+			tag {
+				key = "team"
+				value = "data"
+			}
+
+			tag {
+				key = "team"
+				value = "platform"
+			}
+		}
+		`, name)
+}
+
+func TestAccAivenProject_clone(t *testing.T) {
+	resourceName := "aiven_project.clone"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenProjectResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccProjectResourceCloneItself(rName),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("cannot be cloned from itself"),
+			},
+			{
+				Config: testAccProjectResourceClone(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "project", fmt.Sprintf("test-acc-pr-clone-%s", rName)),
+					resource.TestCheckResourceAttrPair(resourceName, "billing_group", "aiven_billing_group.foo", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "cloned.0.billing_group", "aiven_billing_group.foo", "id"),
+					resource.TestCheckResourceAttr(resourceName, "cloned.0.vpc_ids.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "cloned.0.team_ids.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "cloned.0.integration_endpoint_ids.#", "1"),
+					testAccCheckAivenProjectClonedEndpointSecret(resourceName, "basic_auth_password", "test-acc-password"),
+				),
+			},
+		},
+	})
+}
+
+func testAccProjectResourceClone(name string) string {
+	return fmt.Sprintf(`
+		resource "aiven_account" "foo" {
+			name = "test-acc-ac-%s"
+		}
+
+		resource "aiven_account_team" "foo" {
+			account_id = aiven_account.foo.account_id
+			name = "test-acc-team-%s"
+		}
+
+		resource "aiven_billing_group" "foo" {
+			name = "test-acc-bg-%s"
+			billing_currency = "EUR"
+		}
+
+		resource "aiven_project" "foo" {
+			project = "test-acc-pr-%s"
+			account_id = aiven_account.foo.account_id
+			billing_group = aiven_billing_group.foo.id
+			default_cloud = "aws-eu-west-2"
+		}
+
+		resource "aiven_account_team_project" "foo" {
+			account_id = aiven_account.foo.account_id
+			team_id = aiven_account_team.foo.team_id
+			project_name = aiven_project.foo.project
+			team_type = "read_only"
+		}
+
+		resource "aiven_service_integration_endpoint" "foo" {
+			project = aiven_project.foo.project
+			endpoint_name = "test-acc-prometheus-%s"
+			endpoint_type = "prometheus"
+
+			prometheus_user_config {
+				basic_auth_username = "test-acc-user"
+				basic_auth_password = "test-acc-password"
+			}
+		}
+
+		resource "aiven_project" "clone" {
+			project = "test-acc-pr-clone-%s"
+			account_id = aiven_account.foo.account_id
+
+			clone {
+				source_project = aiven_project.foo.project
+				team_grants = true
+				vpcs = true
+				integration_endpoints = true
+			}
+
+			depends_on = [aiven_account_team_project.foo, aiven_service_integration_endpoint.foo]
+		}
+		`, name, name, name, name, name, name)
+}
+
+// testAccCheckAivenProjectClonedEndpointSecret checks a secret of the user configuration of the
+// integration endpoint copied to a project
+func testAccCheckAivenProjectClonedEndpointSecret(n, key, want string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		c := testAccProvider.Meta().(*providerMeta).client
+
+		r, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("resource %s not found", n)
+		}
+
+		a := r.Primary.Attributes
+		e, err := getIntegrationEndpointWithSecrets(c, a["project"], a["cloned.0.integration_endpoint_ids.0"])
+		if err != nil {
+			return err
+		}
+
+		if got := e.UserConfig[key]; got != want {
+			return fmt.Errorf("%s of the copied integration endpoint is %v, want %s", key, got, want)
+		}
+
+		return nil
+	}
+}
+
+func testAccProjectResourceCloneItself(name string) string {
+	return fmt.Sprintf(`
+		resource "aiven_project" "clone" {
+			project = "test-acc-pr-clone-%s"
+
+			clone {
+				source_project = "test-acc-pr-clone-%s"
+			}
+		}
+		`, name, name)
+}
+
+func TestAccAivenProject_move(t *testing.T) {
+	resourceName := "aiven_project.foo"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenProjectResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProjectResourceMove(rName, "foo"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "account_id", "aiven_account.foo", "account_id"),
+					resource.TestCheckResourceAttrPair(resourceName, "billing_group", "aiven_billing_group.foo", "id"),
+				),
+			},
+			{
+				Config: testAccProjectResourceMove(rName, "bar"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "account_id", "aiven_account.bar", "account_id"),
+					resource.TestCheckResourceAttrPair(resourceName, "billing_group", "aiven_billing_group.bar", "id"),
+					resource.TestCheckResourceAttr(resourceName, "lost_team_grants.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "lost_team_grants.0.team_type", "developer"),
+				),
+			},
+		},
+	})
+}
+
+func testAccProjectResourceMove(name, target string) string {
+	// the grant of the team of the old account is removed by the move
+	grant := ""
+	if target == "foo" {
+		grant = `
+		resource "aiven_account_team_project" "foo" {
+			account_id = aiven_account.foo.account_id
+			team_id = aiven_account_team.foo.team_id
+			project_name = aiven_project.foo.project
+			team_type = "developer"
+		}`
+	}
+
+	return fmt.Sprintf(`
+		resource "aiven_account" "foo" {
+			name = "test-acc-ac-foo-%s"
+		}
+
+		resource "aiven_account" "bar" {
+			name = "test-acc-ac-bar-%s"
+		}
+
+		resource "aiven_account_team" "foo" {
+			account_id = aiven_account.foo.account_id
+			name = "test-acc-team-%s"
+		}
+
+		resource "aiven_billing_group" "foo" {
+			name = "test-acc-bg-foo-%s"
+			account_id = aiven_account.foo.account_id
+			billing_currency = "EUR"
+		}
+
+		resource "aiven_billing_group" "bar" {
+			name = "test-acc-bg-bar-%s"
+			account_id = aiven_account.bar.account_id
+			billing_currency = "EUR"
+		}
+
+		resource "aiven_project" "foo" {
+			project = "test-acc-pr-%s"
+			account_id = aiven_account.%s.account_id
+			billing_group = aiven_billing_group.%s.id
+			default_cloud = "aws-eu-west-2"
+		}
+		%s
+		`, name, name, name, name, name, name, target, target, grant)
+}
+
+func testAccProjectResourceTags(name, team string) string {
+	return fmt.Sprintf(`
+		resource "aiven_project" "foo" {
+			project = "test-acc-pr-%s"
+			default_cloud = "aws-eu-west-2"
+			billing_currency = "EUR"
+
+			tag {
+				key = "team"
+				value = "%s"
+			}
+
+			tag {
+				key = "environment"
+				value = "test"
+			}
+		}
+
+		data "aiven_project" "project" {
+			project = aiven_project.foo.project
+			depends_on = [aiven_project.foo]
+		}
+		`, name, team)
+}
+
 func testAccProjectResourceAccounts(name string) string {
 	return fmt.Sprintf(`
 		resource "aiven_account" "foo" {
@@ -218,7 +505,7 @@ func testAccCheckAivenProjectAttributes(n string, attributes ...string) resource
 }
 
 func testAccCheckAivenProjectResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each project is destroyed
 	for _, rs := range s.RootModule().Resources {
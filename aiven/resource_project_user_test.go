@@ -35,7 +35,7 @@ func TestAccAivenProjectUser_basic(t *testing.T) {
 }
 
 func testAccCheckAivenProjectUserResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each project is destroyed
 	for _, rs := range s.RootModule().Resources {
@@ -5,6 +5,7 @@ package aiven
 import (
 	"context"
 	"fmt"
+	"log"
 	"strconv"
 	"strings"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"github.com/aiven/aiven-go-client"
 	"github.com/aiven/terraform-provider-aiven/pkg/ipfilter"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -64,7 +66,7 @@ func serviceCommonSchema() map[string]*schema.Schema {
 		"plan": {
 			Type:        schema.TypeString,
 			Optional:    true,
-			Description: "Defines what kind of computing resources are allocated for the service. It can be changed after creation, though there are some restrictions when going to a smaller plan such as the new plan must have sufficient amount of disk space to store all current data and switching to a plan with fewer nodes might not be supported. The basic plan names are `hobbyist`, `startup-x`, `business-x` and `premium-x` where `x` is (roughly) the amount of memory on each node (also other attributes like number of CPUs and amount of disk space varies but naming is based on memory). The available options can be seem from the [Aiven pricing page](https://aiven.io/pricing).",
+			Description: "Defines what kind of computing resources are allocated for the service. It can be changed after creation, though there are some restrictions when going to a smaller plan such as the new plan must have sufficient amount of disk space to store all current data and switching to a plan with fewer nodes might not be supported. The basic plan names are `hobbyist`, `startup-x`, `business-x` and `premium-x` where `x` is (roughly) the amount of memory on each node (also other attributes like number of CPUs and amount of disk space varies but naming is based on memory). The available options can be seen from the `aiven_service_plans` data source or the [Aiven pricing page](https://aiven.io/pricing).",
 		},
 		"service_name": {
 			Type:        schema.TypeString,
@@ -85,18 +87,14 @@ func serviceCommonSchema() map[string]*schema.Schema {
 		"maintenance_window_dow": {
 			Type:        schema.TypeString,
 			Optional:    true,
+			Computed:    true,
 			Description: "Day of week when maintenance operations should be performed. One monday, tuesday, wednesday, etc.",
-			DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
-				return new == ""
-			},
 		},
 		"maintenance_window_time": {
 			Type:        schema.TypeString,
 			Optional:    true,
+			Computed:    true,
 			Description: "Time of day when maintenance operations should be performed. UTC time in HH:mm:ss format.",
-			DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
-				return new == ""
-			},
 		},
 		"termination_protection": {
 			Type:        schema.TypeBool,
@@ -135,6 +133,31 @@ func serviceCommonSchema() map[string]*schema.Schema {
 			Computed:    true,
 			Description: "Service state. One of `POWEROFF`, `REBALANCING`, `REBUILDING` or `RUNNING`.",
 		},
+		"wait_for_migration": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: complex("Makes updates changing `plan`, `cloud_name` or `project_vpc_id` wait until the resulting migration of the service has finished and the service is `RUNNING` again, by default updates return as soon as the migration has started.").defaultValue(false).build(),
+		},
+		"powered": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: complex("Powers the service on or off. A powered off service keeps its configuration and backups but has no running nodes, resources depending on the service keep their state while it is powered off.").defaultValue(true).build(),
+		},
+		"tag":                   serviceTagSchema(),
+		"tags_all":              serviceTagsAllSchema(),
+		"maintenance_updates":   serviceMaintenanceUpdatesSchema(),
+		"additional_disk_space": serviceAdditionalDiskSpaceSchema(),
+		"disk_space_used":       serviceDiskSpaceUsedSchema(),
+		"disk_space_cap":        serviceDiskSpaceCapSchema(),
+		"cost_estimate":         serviceCostEstimateSchema(),
+		"apply_pending_maintenance": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: complex("Applies the pending `maintenance_updates` of the service during apply instead of waiting for the maintenance window. The service is rebuilt on new nodes while the updates are applied. Updates that cannot be started yet are left for the maintenance window.").defaultValue(false).build(),
+		},
 		"service_integrations": {
 			Type:        schema.TypeList,
 			Optional:    true,
@@ -242,18 +265,14 @@ var aivenServiceSchema = map[string]*schema.Schema{
 	"maintenance_window_dow": {
 		Type:        schema.TypeString,
 		Optional:    true,
+		Computed:    true,
 		Description: "Day of week when maintenance operations should be performed. One monday, tuesday, wednesday, etc.",
-		DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
-			return new == ""
-		},
 	},
 	"maintenance_window_time": {
 		Type:        schema.TypeString,
 		Optional:    true,
+		Computed:    true,
 		Description: "Time of day when maintenance operations should be performed. UTC time in HH:mm:ss format.",
-		DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
-			return new == ""
-		},
 	},
 	"termination_protection": {
 		Type:        schema.TypeBool,
@@ -359,6 +378,37 @@ var aivenServiceSchema = map[string]*schema.Schema{
 		Computed:    true,
 		Description: "Service state. One of `POWEROFF`, `REBALANCING`, `REBUILDING` and `RUNNING`.",
 	},
+	"powered": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     true,
+		Description: "Power the service on or off",
+	},
+	"wait_for_migration": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Wait for plan, cloud and VPC migrations of the service to finish on update",
+	},
+	"upgrade_dry_run": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Run the upgrade check of a major version upgrade during plan, only supported by pg services",
+	},
+	"tag":                   serviceTagSchema(),
+	"tags_all":              serviceTagsAllSchema(),
+	"maintenance_updates":   serviceMaintenanceUpdatesSchema(),
+	"additional_disk_space": serviceAdditionalDiskSpaceSchema(),
+	"disk_space_used":       serviceDiskSpaceUsedSchema(),
+	"disk_space_cap":        serviceDiskSpaceCapSchema(),
+	"cost_estimate":         serviceCostEstimateSchema(),
+	"apply_pending_maintenance": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Apply the pending maintenance updates of the service during apply",
+	},
 	"cassandra": {
 		Type:        schema.TypeList,
 		Computed:    true,
@@ -590,6 +640,7 @@ func resourceService() *schema.Resource {
 		ReadContext:        resourceServiceRead,
 		UpdateContext:      resourceServiceUpdate,
 		DeleteContext:      resourceServiceDelete,
+		CustomizeDiff:      resourceServiceCustomizeDiffWrapper("service"),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceState,
 		},
@@ -602,6 +653,18 @@ func resourceService() *schema.Resource {
 	}
 }
 
+// resourceServiceCustomizeDiffWrapper returns the plan time logic shared by all service resources
+func resourceServiceCustomizeDiffWrapper(serviceType string) schema.CustomizeDiffFunc {
+	return customdiff.Sequence(
+		resourceServiceUpgradeCustomizeDiff,
+		resourceServiceMaintenanceCustomizeDiff,
+		resourceServiceDiskSpaceCustomizeDiff,
+		resourceServiceTagsCustomizeDiff,
+		resourceServicePolicyCustomizeDiff(serviceType),
+		resourceServiceCostEstimateCustomizeDiff(serviceType),
+	)
+}
+
 func resourceServiceCreateWrapper(serviceType string) schema.CreateContextFunc {
 	if serviceType == "service" {
 		return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -661,7 +724,7 @@ func resourceServiceCreateWrapper(serviceType string) schema.CreateContextFunc {
 }
 
 func resourceServiceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 	serviceType := d.Get("service_type").(string)
 	userConfig := ConvertTerraformUserConfigToAPICompatibleFormat("service", serviceType, true, d)
 	vpcID := d.Get("project_vpc_id").(string)
@@ -713,19 +776,51 @@ func resourceServiceCreate(ctx context.Context, d *schema.ResourceData, m interf
 
 	d.SetId(buildResourceID(d.Get("project").(string), service.Name))
 
+	// the tags and the additional disk space cannot be given when creating a service
+	if d.Get("tag").(*schema.Set).Len() > 0 || len(m.(*providerMeta).defaultTags) > 0 {
+		if err := resourceServiceUpdateTags(d, m); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if _, ok := d.GetOk("additional_disk_space"); ok {
+		if resized, err := resourceServiceUpdateDiskSpace(ctx, d, m, "create"); err != nil {
+			return diag.FromErr(err)
+		} else if resized != nil {
+			service = resized
+		}
+	}
+
+	// services are always created powered on, power off afterwards when requested
+	if !d.Get("powered").(bool) {
+		service, err = resourceServicePowerOff(ctx, d, m, service)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	err = copyServicePropertiesFromAPIResponseToTerraform(d, service, d.Get("project").(string))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	details, err := getServiceDetails(client, d.Get("project").(string), service.Name)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("cannot get details of service %s: %s", service.Name, err))
+	}
+
+	err = copyServiceDetailsFromAPIResponseToTerraform(d, m, d.Get("project").(string), service, details)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	return nil
 }
 
 func resourceServiceRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, serviceName := splitResourceID2(d.Id())
-	service, err := client.Services.Get(projectName, serviceName)
+	service, details, err := getService(client, projectName, serviceName)
 	if err != nil {
 		if err = resourceReadHandleNotFound(err, d); err != nil {
 			return diag.FromErr(fmt.Errorf("unable to GET service %s: %s", d.Id(), err))
@@ -738,16 +833,26 @@ func resourceServiceRead(_ context.Context, d *schema.ResourceData, m interface{
 		return diag.FromErr(err)
 	}
 
+	err = copyServiceDetailsFromAPIResponseToTerraform(d, m, projectName, service, details)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	return nil
 }
 
 func resourceServiceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	if d.HasChanges("service_integrations") && len(d.Get("service_integrations").([]interface{})) != 0 {
 		return diag.Errorf("service_integrations field can only be set during creation of a service")
 	}
 
+	upgrade, diags := resourceServiceUpgradeCheck(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
 	projectName, serviceName := splitResourceID2(d.Id())
 	userConfig := ConvertTerraformUserConfigToAPICompatibleFormat("service", d.Get("service_type").(string), false, d)
 	vpcID := d.Get("project_vpc_id").(string)
@@ -764,7 +869,7 @@ func resourceServiceUpdate(ctx context.Context, d *schema.ResourceData, m interf
 			MaintenanceWindow:     getMaintenanceWindow(d),
 			Plan:                  d.Get("plan").(string),
 			ProjectVPCID:          vpcIDPointer,
-			Powered:               true,
+			Powered:               d.Get("powered").(bool),
 			TerminationProtection: d.Get("termination_protection").(bool),
 			UserConfig:            userConfig,
 		},
@@ -773,21 +878,68 @@ func resourceServiceUpdate(ctx context.Context, d *schema.ResourceData, m interf
 		return diag.FromErr(err)
 	}
 
-	service, err := resourceServiceWait(ctx, d, m, "update")
+	operation := "update"
+	if d.HasChange("powered") && d.Get("powered").(bool) {
+		// a service being powered on has to be fully running before its dependents are managed
+		operation = "power_on"
+	}
+	if upgrade {
+		// dependents of an upgraded service are managed once the new version is running
+		operation = "upgrade"
+	}
+	if d.Get("wait_for_migration").(bool) && d.HasChanges("plan", "cloud_name", "project_vpc_id") {
+		operation = "migration"
+	}
+
+	service, err := resourceServiceWait(ctx, d, m, operation)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChanges("tag", "tags_all") {
+		if err := resourceServiceUpdateTags(d, m); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChanges("additional_disk_space", "plan") {
+		resized, err := resourceServiceUpdateDiskSpace(ctx, d, m, "update")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if resized != nil {
+			service = resized
+		}
+	}
+
+	maintained, err := resourceServiceApplyPendingMaintenance(ctx, d, m)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if maintained != nil {
+		service = maintained
+	}
 
 	err = copyServicePropertiesFromAPIResponseToTerraform(d, service, projectName)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	return nil
+	details, err := getServiceDetails(client, projectName, serviceName)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("cannot get details of service %s: %s", serviceName, err))
+	}
+
+	err = copyServiceDetailsFromAPIResponseToTerraform(d, m, projectName, service, details)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
 }
 
 func resourceServiceDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, serviceName := splitResourceID2(d.Id())
 
@@ -800,14 +952,14 @@ func resourceServiceDelete(_ context.Context, d *schema.ResourceData, m interfac
 }
 
 func resourceServiceState(_ context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	if len(strings.Split(d.Id(), "/")) != 2 {
 		return nil, fmt.Errorf("invalid identifier %v, expected <project_name>/<service_name>", d.Id())
 	}
 
 	projectName, serviceName := splitResourceID2(d.Id())
-	service, err := client.Services.Get(projectName, serviceName)
+	service, details, err := getService(client, projectName, serviceName)
 	if err != nil {
 		return nil, fmt.Errorf("unable to GET service %s: %s", d.Id(), err)
 	}
@@ -817,32 +969,69 @@ func resourceServiceState(_ context.Context, d *schema.ResourceData, m interface
 		return nil, err
 	}
 
+	err = copyServiceDetailsFromAPIResponseToTerraform(d, m, projectName, service, details)
+	if err != nil {
+		return nil, err
+	}
+
 	return []*schema.ResourceData{d}, nil
 }
 
 func resourceServiceWait(ctx context.Context, d *schema.ResourceData, m interface{}, operation string) (*aiven.Service, error) {
 	var timeout time.Duration
-	if operation == "create" {
+	if operation == "create" || operation == "power_off" {
 		timeout = d.Timeout(schema.TimeoutCreate)
 	} else {
 		timeout = d.Timeout(schema.TimeoutUpdate)
 	}
 
 	w := &ServiceChangeWaiter{
-		Client:      m.(*aiven.Client),
+		Client:      m.(*providerMeta).client,
 		Operation:   operation,
 		Project:     d.Get("project").(string),
 		ServiceName: d.Get("service_name").(string),
 	}
 
+	// not all resources managing services have the powered field, services are always created
+	// powered on and are only powered off by resourceServicePowerOff
+	if powered, ok := d.Get("powered").(bool); ok && operation != "create" {
+		w.PoweredOff = !powered
+	}
+
+	target := aivenTargetState
+	if w.PoweredOff {
+		target = aivenPoweroffState
+	}
+
 	service, err := w.Conf(timeout).WaitForStateContext(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error waiting for Aiven service to be RUNNING: %s", err)
+		return nil, fmt.Errorf("error waiting for Aiven service to be %s: %s", target, err)
 	}
 
 	return service.(*aiven.Service), nil
 }
 
+// resourceServicePowerOff powers off a service that was created powered on
+func resourceServicePowerOff(ctx context.Context, d *schema.ResourceData, m interface{}, service *aiven.Service) (*aiven.Service, error) {
+	client := m.(*providerMeta).client
+
+	projectName, serviceName := splitResourceID2(d.Id())
+	_, err := client.Services.Update(
+		projectName,
+		serviceName,
+		aiven.UpdateServiceRequest{
+			ProjectVPCID:          service.ProjectVPCID,
+			Powered:               false,
+			TerminationProtection: service.TerminationProtection,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return resourceServiceWait(ctx, d, m, "power_off")
+}
+
 func getMaintenanceWindow(d *schema.ResourceData) *aiven.MaintenanceWindow {
 	dow := d.Get("maintenance_window_dow").(string)
 	t := d.Get("maintenance_window_time").(string)
@@ -871,6 +1060,9 @@ func copyServicePropertiesFromAPIResponseToTerraform(
 	if err := d.Set("state", service.State); err != nil {
 		return err
 	}
+	if err := d.Set("powered", service.Powered); err != nil {
+		return err
+	}
 	if err := d.Set("plan", service.Plan); err != nil {
 		return err
 	}
@@ -936,6 +1128,49 @@ func copyServicePropertiesFromAPIResponseToTerraform(
 	return copyConnectionInfoFromAPIResponseToTerraform(d, serviceType, service.ConnectionInfo)
 }
 
+// copyServiceDetailsFromAPIResponseToTerraform sets the fields of the service that are not
+// decoded by aiven-go-client
+func copyServiceDetailsFromAPIResponseToTerraform(
+	d *schema.ResourceData,
+	m interface{},
+	project string,
+	service *aiven.Service,
+	details *serviceDetails,
+) error {
+	client := m.(*providerMeta).client
+
+	if err := d.Set("maintenance_updates", flattenServiceMaintenanceUpdates(details.Maintenance.Updates)); err != nil {
+		return err
+	}
+	if err := copyServiceTagsFromAPIResponseToTerraform(d, m, details.Tags); err != nil {
+		return err
+	}
+
+	plan, err := getServicePlan(m, project, service.Type, service.Plan)
+	if err != nil {
+		// services on plans that are no longer offered keep the disk space and the cost
+		// estimate of their state
+		log.Printf("[WARN] cannot get disk space of service %s: %s", service.Name, err)
+		return nil
+	}
+	if err := d.Set("cost_estimate", flattenServiceCostEstimate(plan, service.CloudName)); err != nil {
+		return err
+	}
+
+	diskSpace := getServiceDiskSpace(client, project, service, details, plan)
+	if err := d.Set("additional_disk_space", diskSpace.Additional); err != nil {
+		return err
+	}
+	// the previous usage is kept when the usage of the service is not known
+	if diskSpace.Used != nil {
+		if err := d.Set("disk_space_used", *diskSpace.Used); err != nil {
+			return err
+		}
+	}
+
+	return d.Set("disk_space_cap", diskSpace.Cap)
+}
+
 func flattenServiceComponents(r *aiven.Service) []map[string]interface{} {
 	var components []map[string]interface{}
 
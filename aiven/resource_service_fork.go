@@ -0,0 +1,470 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// serviceForkTypes lists the service types with backups that a new service can be forked from
+var serviceForkTypes = map[string]bool{
+	ServiceTypeCassandra:     true,
+	ServiceTypeElasticsearch: true,
+	ServiceTypeGrafana:       true,
+	ServiceTypeInfluxDB:      true,
+	ServiceTypeM3:            true,
+	ServiceTypeMySQL:         true,
+	ServiceTypeOpensearch:    true,
+	ServiceTypePG:            true,
+	ServiceTypeRedis:         true,
+}
+
+// serviceForkRecoveryTargetTimeTypes lists the service types supporting point-in-time forks
+var serviceForkRecoveryTargetTimeTypes = map[string]bool{
+	ServiceTypePG:    true,
+	ServiceTypeMySQL: true,
+}
+
+// serviceForkBackupNameTypes lists the service types that can be forked from a named backup
+var serviceForkBackupNameTypes = map[string]bool{
+	ServiceTypeElasticsearch: true,
+	ServiceTypeGrafana:       true,
+	ServiceTypeInfluxDB:      true,
+	ServiceTypeOpensearch:    true,
+	ServiceTypeRedis:         true,
+}
+
+var aivenServiceForkSchema = map[string]*schema.Schema{
+	"project": commonSchemaProjectReference,
+	"source_project": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		ForceNew:    true,
+		Description: complex("The project of the service that is forked, defaults to `project`.").forceNew().referenced().build(),
+	},
+	"source_service_name": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: complex("The name of the service that is forked.").forceNew().referenced().build(),
+	},
+	"service_name": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: complex("The name of the forked service.").forceNew().build(),
+	},
+	"recovery_target_time": {
+		Type:          schema.TypeString,
+		Optional:      true,
+		ForceNew:      true,
+		ConflictsWith: []string{"backup_name"},
+		ValidateFunc:  validation.IsRFC3339Time,
+		Description:   complex("Point in time in RFC3339 format the source service is restored to in the fork, it has to be between the oldest backup of the source service and the current time. Defaults to the latest backup. Only supported by PostgreSQL and MySQL services.").forceNew().build(),
+	},
+	"backup_name": {
+		Type:          schema.TypeString,
+		Optional:      true,
+		ForceNew:      true,
+		ConflictsWith: []string{"recovery_target_time"},
+		Description:   complex("Name of the backup of the source service to restore in the fork, defaults to the latest backup. Only supported by Elasticsearch, Grafana, InfluxDB, OpenSearch and Redis services.").forceNew().build(),
+	},
+	"plan": {
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Defines what kind of computing resources are allocated for the forked service.",
+	},
+	"cloud_name": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "Defines the cloud provider and region where the forked service is hosted in. Defaults to the cloud of the source service.",
+	},
+	"project_vpc_id": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Specifies the VPC the forked service should run in. If the value is not set the service is not run inside a VPC.",
+	},
+	"termination_protection": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Description: "Prevents the forked service from being deleted.",
+	},
+	"service_type": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Aiven internal service type code, the same as the type of the source service.",
+	},
+	"state": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Service state. One of `POWEROFF`, `REBALANCING`, `REBUILDING` or `RUNNING`.",
+	},
+	"progress": {
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Progress of restoring the data of the source service on the nodes of the fork, empty once the fork is complete.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"node": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Name of the node",
+				},
+				"phase": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Name of the restore phase",
+				},
+				"current": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "Current progress of the phase",
+				},
+				"max": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "Progress value the phase completes at",
+				},
+				"unit": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Unit of the progress values",
+				},
+			},
+		},
+	},
+	"service_uri": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Sensitive:   true,
+		Description: "URI for connecting to the forked service.",
+	},
+	"service_host": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The hostname of the forked service.",
+	},
+	"service_port": {
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "The port of the forked service.",
+	},
+}
+
+func resourceServiceFork() *schema.Resource {
+	return &schema.Resource{
+		Description:   "The Service Fork resource allows the creation and management of Aiven services forked from the backups of another service. Services without backups, such as Kafka services, cannot be forked.",
+		CreateContext: resourceServiceForkCreate,
+		ReadContext:   resourceServiceForkRead,
+		UpdateContext: resourceServiceForkUpdate,
+		DeleteContext: resourceServiceDelete,
+		CustomizeDiff: resourceServiceForkCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceServiceForkState,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(40 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: aivenServiceForkSchema,
+	}
+}
+
+// resourceServiceForkCreate creates a service from the backups of the source service
+func resourceServiceForkCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	sourceServiceName := d.Get("source_service_name").(string)
+	sourceProjectName := d.Get("source_project").(string)
+	if sourceProjectName == "" {
+		sourceProjectName = projectName
+	}
+
+	source, err := client.Services.Get(sourceProjectName, sourceServiceName)
+	if err != nil {
+		return diag.Errorf("cannot get source service %s: %s", sourceServiceName, err)
+	}
+
+	userConfig := map[string]interface{}{
+		"project_to_fork_from": sourceProjectName,
+		"service_to_fork_from": sourceServiceName,
+	}
+	t, recoveryTargetTime := d.GetOk("recovery_target_time")
+	backupName, restoreBackup := d.GetOk("backup_name")
+	if err := validateServiceForkSource(source.Type, recoveryTargetTime, restoreBackup); err != nil {
+		return diag.FromErr(err)
+	}
+	if recoveryTargetTime {
+		if err := validateServiceRecoveryTargetTime(t.(string), source.Backups, time.Now()); err != nil {
+			return diag.FromErr(err)
+		}
+		userConfig["recovery_target_time"] = t.(string)
+	}
+	if restoreBackup {
+		userConfig["recovery_basebackup_name"] = backupName.(string)
+	}
+
+	cloudName := d.Get("cloud_name").(string)
+	if cloudName == "" {
+		cloudName = source.CloudName
+	}
+
+	_, err = client.Services.Create(
+		projectName,
+		aiven.CreateServiceRequest{
+			Cloud:                 cloudName,
+			Plan:                  d.Get("plan").(string),
+			ProjectVPCID:          serviceProjectVPCID(d),
+			ServiceName:           serviceName,
+			ServiceType:           source.Type,
+			TerminationProtection: d.Get("termination_protection").(bool),
+			UserConfig:            userConfig,
+		},
+	)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(buildResourceID(projectName, serviceName))
+
+	if err := d.Set("source_project", sourceProjectName); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// the waiter logs the restore progress of the nodes while the fork is being built
+	if _, err := resourceServiceWait(ctx, d, m, "create"); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceServiceForkRead(ctx, d, m)
+}
+
+// resourceServiceForkCustomizeDiff validates the source service of a new fork and the recovery
+// target time against its backups already during plan when the source service exists
+func resourceServiceForkCustomizeDiff(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	source, err := getServiceForkSource(d, m)
+	if err != nil || source == nil {
+		return err
+	}
+
+	t, recoveryTargetTime := d.GetOk("recovery_target_time")
+	_, restoreBackup := d.GetOk("backup_name")
+	if err := validateServiceForkSource(source.Type, recoveryTargetTime, restoreBackup); err != nil {
+		return err
+	}
+	if !recoveryTargetTime {
+		return nil
+	}
+
+	return validateServiceRecoveryTargetTime(t.(string), source.Backups, time.Now())
+}
+
+// getServiceForkSource returns the source service of a fork that is being created, nil when the
+// source service is not known yet
+func getServiceForkSource(d *schema.ResourceDiff, m interface{}) (*aiven.Service, error) {
+	if d.Id() != "" || !d.NewValueKnown("source_service_name") {
+		return nil, nil
+	}
+
+	projectName, ok := serviceForkSourceProject(
+		d.Get("source_project").(string), d.NewValueKnown("source_project"),
+		d.Get("project").(string), d.NewValueKnown("project"),
+	)
+	if !ok {
+		return nil, nil
+	}
+
+	source, err := m.(*providerMeta).client.Services.Get(projectName, d.Get("source_service_name").(string))
+	if err != nil {
+		if aiven.IsNotFound(err) {
+			// the source service is created in the same apply
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return source, nil
+}
+
+// serviceForkSourceProject returns the project of the source service of a fork, the project of
+// the fork is used when source_project is not set or not known yet as source_project is only
+// computed from it during apply, false is returned when the project is not known
+func serviceForkSourceProject(sourceProject string, sourceProjectKnown bool, project string, projectKnown bool) (string, bool) {
+	if sourceProjectKnown && sourceProject != "" {
+		return sourceProject, true
+	}
+
+	return project, projectKnown && project != ""
+}
+
+// validateServiceForkSource checks that a service of the given type can be forked with the
+// requested restore options
+func validateServiceForkSource(serviceType string, recoveryTargetTime, backupName bool) error {
+	if !serviceForkTypes[serviceType] {
+		return fmt.Errorf("%s services cannot be forked, only services with backups can be forked", serviceType)
+	}
+
+	if recoveryTargetTime && !serviceForkRecoveryTargetTimeTypes[serviceType] {
+		return fmt.Errorf("recovery_target_time is not supported by %s services", serviceType)
+	}
+
+	if backupName && !serviceForkBackupNameTypes[serviceType] {
+		return fmt.Errorf("backup_name is not supported by %s services", serviceType)
+	}
+
+	return nil
+}
+
+// validateServiceRecoveryTargetTime checks that a point-in-time restore targets the window
+// between the oldest backup of a service and the current time
+func validateServiceRecoveryTargetTime(target string, backups []*aiven.Backup, now time.Time) error {
+	t, err := time.Parse(time.RFC3339, target)
+	if err != nil {
+		return fmt.Errorf("invalid recovery_target_time %q: %s", target, err)
+	}
+
+	if t.After(now) {
+		return fmt.Errorf("recovery_target_time %s is in the future", target)
+	}
+
+	var oldest time.Time
+	for _, b := range backups {
+		bt := serviceBackupTime(b.BackupTime)
+		if !bt.IsZero() && (oldest.IsZero() || bt.Before(oldest)) {
+			oldest = bt
+		}
+	}
+
+	if oldest.IsZero() {
+		return fmt.Errorf("the source service has no backups to restore")
+	}
+
+	if t.Before(oldest) {
+		return fmt.Errorf("recovery_target_time %s is outside of the backup retention window starting at %s",
+			target, oldest.UTC().Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+func resourceServiceForkRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	projectName, serviceName := splitResourceID2(d.Id())
+	service, err := client.Services.Get(projectName, serviceName)
+	if err != nil {
+		return diag.FromErr(resourceReadHandleNotFound(err, d))
+	}
+
+	if err := d.Set("project", projectName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := copyServiceReadReplicaPropertiesFromAPIResponseToTerraform(d, service); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if v, ok := service.UserConfig["project_to_fork_from"].(string); ok && v != "" {
+		if err := d.Set("source_project", v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if v, ok := service.UserConfig["service_to_fork_from"].(string); ok && v != "" {
+		if err := d.Set("source_service_name", v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if v, ok := service.UserConfig["recovery_target_time"].(string); ok && v != "" && d.Get("recovery_target_time").(string) == "" {
+		if err := d.Set("recovery_target_time", v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if v, ok := service.UserConfig["recovery_basebackup_name"].(string); ok && v != "" {
+		if err := d.Set("backup_name", v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err := d.Set("progress", flattenServiceForkProgress(service)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceServiceForkUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	projectName, serviceName := splitResourceID2(d.Id())
+	_, err := client.Services.Update(
+		projectName,
+		serviceName,
+		aiven.UpdateServiceRequest{
+			Cloud:                 d.Get("cloud_name").(string),
+			Plan:                  d.Get("plan").(string),
+			ProjectVPCID:          serviceProjectVPCID(d),
+			Powered:               true,
+			TerminationProtection: d.Get("termination_protection").(bool),
+		},
+	)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if _, err := resourceServiceWait(ctx, d, m, "update"); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceServiceForkRead(ctx, d, m)
+}
+
+func resourceServiceForkState(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if len(strings.Split(d.Id(), "/")) != 2 {
+		return nil, fmt.Errorf("invalid identifier %v, expected <project_name>/<service_name>", d.Id())
+	}
+
+	di := resourceServiceForkRead(ctx, d, m)
+	if di.HasError() {
+		return nil, fmt.Errorf("cannot get service fork: %v", di)
+	}
+
+	if d.Get("source_service_name").(string) == "" {
+		return nil, fmt.Errorf("service %s is not a fork of another service", d.Id())
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// flattenServiceForkProgress returns the unfinished progress updates of the service nodes
+func flattenServiceForkProgress(service *aiven.Service) []map[string]interface{} {
+	progress := make([]map[string]interface{}, 0)
+
+	for _, n := range service.NodeStates {
+		for _, p := range n.ProgressUpdates {
+			if p.Completed {
+				continue
+			}
+
+			progress = append(progress, map[string]interface{}{
+				"node":    n.Name,
+				"phase":   p.Phase,
+				"current": p.Current,
+				"max":     p.Max,
+				"unit":    p.Unit,
+			})
+		}
+	}
+
+	return progress
+}
@@ -0,0 +1,298 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAivenServiceFork_basic(t *testing.T) {
+	resourceName := "aiven_service_fork.foo"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenServiceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceForkResource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "project", os.Getenv("AIVEN_PROJECT_NAME")),
+					resource.TestCheckResourceAttr(resourceName, "source_project", os.Getenv("AIVEN_PROJECT_NAME")),
+					resource.TestCheckResourceAttr(resourceName, "source_service_name", fmt.Sprintf("test-acc-sr-%s", rName)),
+					resource.TestCheckResourceAttr(resourceName, "service_type", "pg"),
+					resource.TestCheckResourceAttr(resourceName, "state", "RUNNING"),
+				),
+			},
+		},
+	})
+}
+
+func testAccServiceForkResource(name string) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_pg" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-4"
+			service_name = "test-acc-sr-%s"
+		}
+
+		resource "aiven_service_fork" "foo" {
+			project = data.aiven_project.foo.project
+			source_service_name = aiven_pg.bar.service_name
+			service_name = "test-acc-sr-fork-%s"
+			plan = "startup-4"
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name, name)
+}
+
+func TestAccAivenServiceFork_kafka(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenServiceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccServiceForkKafkaResource(rName),
+				ExpectError: regexp.MustCompile("kafka services cannot be forked"),
+			},
+		},
+	})
+}
+
+func testAccServiceForkKafkaResource(name string) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_kafka" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-2"
+			service_name = "test-acc-sr-%s"
+		}
+
+		resource "aiven_service_fork" "foo" {
+			project = data.aiven_project.foo.project
+			source_service_name = aiven_kafka.bar.service_name
+			service_name = "test-acc-sr-fork-%s"
+			plan = "startup-2"
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name, name)
+}
+
+// TestAccAivenServiceFork_omittedSourceProject checks that the recovery target time of a fork
+// of an existing service is validated during plan when source_project is omitted
+func TestAccAivenServiceFork_omittedSourceProject(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenServiceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceForkSourceResource(rName, ""),
+			},
+			{
+				Config: testAccServiceForkSourceResource(rName, fmt.Sprintf(`
+					resource "aiven_service_fork" "foo" {
+						project = data.aiven_project.foo.project
+						source_service_name = aiven_pg.bar.service_name
+						service_name = "test-acc-sr-fork-%s"
+						plan = "startup-4"
+						recovery_target_time = "2099-01-01T00:00:00Z"
+					}
+				`, rName)),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("recovery_target_time .* is in the future"),
+			},
+		},
+	})
+}
+
+func testAccServiceForkSourceResource(name, fork string) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_pg" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-4"
+			service_name = "test-acc-sr-%s"
+		}
+		%s
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name, fork)
+}
+
+func Test_serviceForkSourceProject(t *testing.T) {
+	tests := []struct {
+		name               string
+		sourceProject      string
+		sourceProjectKnown bool
+		project            string
+		projectKnown       bool
+		want               string
+		wantOk             bool
+	}{
+		{"source-project", "source", true, "fork", true, "source", true},
+		{"omitted-source-project", "", false, "fork", true, "fork", true},
+		{"empty-source-project", "", true, "fork", true, "fork", true},
+		{"unknown-project", "", false, "", false, "", false},
+		{"source-project-of-unknown-project", "source", true, "", false, "source", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := serviceForkSourceProject(tt.sourceProject, tt.sourceProjectKnown, tt.project, tt.projectKnown)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("serviceForkSourceProject() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func Test_validateServiceForkSource(t *testing.T) {
+	tests := []struct {
+		name               string
+		serviceType        string
+		recoveryTargetTime bool
+		backupName         bool
+		wantErr            bool
+	}{
+		{
+			"pg",
+			"pg",
+			false,
+			false,
+			false,
+		},
+		{
+			"pg point in time",
+			"pg",
+			true,
+			false,
+			false,
+		},
+		{
+			"pg backup",
+			"pg",
+			false,
+			true,
+			true,
+		},
+		{
+			"redis",
+			"redis",
+			false,
+			false,
+			false,
+		},
+		{
+			"redis point in time",
+			"redis",
+			true,
+			false,
+			true,
+		},
+		{
+			"redis backup",
+			"redis",
+			false,
+			true,
+			false,
+		},
+		{
+			"kafka",
+			"kafka",
+			false,
+			false,
+			true,
+		},
+		{
+			"flink",
+			"flink",
+			false,
+			false,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateServiceForkSource(tt.serviceType, tt.recoveryTargetTime, tt.backupName); (err != nil) != tt.wantErr {
+				t.Errorf("validateServiceForkSource() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_flattenServiceForkProgress(t *testing.T) {
+	service := &aiven.Service{
+		NodeStates: []*aiven.NodeState{
+			{
+				Name: "node-1",
+				ProgressUpdates: []aiven.ProgressUpdate{
+					{Completed: true, Phase: "prepare"},
+					{Phase: "basebackup", Current: 512, Max: 1024, Unit: "bytes_uncompressed"},
+				},
+			},
+			{
+				Name: "node-2",
+			},
+		},
+	}
+
+	want := []map[string]interface{}{
+		{"node": "node-1", "phase": "basebackup", "current": 512, "max": 1024, "unit": "bytes_uncompressed"},
+	}
+
+	if got := flattenServiceForkProgress(service); !reflect.DeepEqual(got, want) {
+		t.Errorf("flattenServiceForkProgress() = %v, want %v", got, want)
+	}
+}
+
+func Test_validateServiceRecoveryTargetTime(t *testing.T) {
+	now := time.Date(2021, 11, 22, 12, 0, 0, 0, time.UTC)
+	backups := []*aiven.Backup{
+		{BackupTime: "2021-11-21T08:00:00Z"},
+		{BackupTime: "2021-11-20T08:00:00Z"},
+	}
+
+	tests := []struct {
+		name    string
+		target  string
+		backups []*aiven.Backup
+		wantErr bool
+	}{
+		{"inside-window", "2021-11-21T10:00:00Z", backups, false},
+		{"oldest-backup", "2021-11-20T08:00:00Z", backups, false},
+		{"before-window", "2021-11-19T08:00:00Z", backups, true},
+		{"future", "2021-11-23T08:00:00Z", backups, true},
+		{"no-backups", "2021-11-21T10:00:00Z", nil, true},
+		{"invalid", "2021-11-21 10:00:00", backups, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateServiceRecoveryTargetTime(tt.target, tt.backups, now); (err != nil) != tt.wantErr {
+				t.Errorf("validateServiceRecoveryTargetTime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
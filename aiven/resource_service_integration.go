@@ -174,7 +174,7 @@ func plainEndpointID(fullEndpointID *string) *string {
 }
 
 func resourceServiceIntegrationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName := d.Get("project").(string)
 	integrationType := d.Get("integration_type").(string)
@@ -215,7 +215,7 @@ func resourceServiceIntegrationCreate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceServiceIntegrationRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, integrationID := splitResourceID2(d.Id())
 	integration, err := client.ServiceIntegrations.Get(projectName, integrationID)
@@ -235,7 +235,7 @@ func resourceServiceIntegrationRead(_ context.Context, d *schema.ResourceData, m
 }
 
 func resourceServiceIntegrationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, integrationID := splitResourceID2(d.Id())
 
@@ -257,7 +257,7 @@ func resourceServiceIntegrationUpdate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceServiceIntegrationDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, integrationID := splitResourceID2(d.Id())
 	err := client.ServiceIntegrations.Delete(projectName, integrationID)
@@ -269,7 +269,7 @@ func resourceServiceIntegrationDelete(_ context.Context, d *schema.ResourceData,
 }
 
 func resourceServiceIntegrationState(_ context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	if len(strings.Split(d.Id(), "/")) != 2 {
 		return nil, fmt.Errorf("invalid identifier %v, expected <project_name>/<integration_id>", d.Id())
@@ -289,7 +289,7 @@ func resourceServiceIntegrationState(_ context.Context, d *schema.ResourceData,
 }
 
 func resourceServiceIntegrationCheckForPreexistingResource(ctx context.Context, d *schema.ResourceData, m interface{}) (*aiven.ServiceIntegration, error) {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName := d.Get("project").(string)
 	integrationType := d.Get("integration_type").(string)
@@ -317,13 +317,24 @@ func resourceServiceIntegrationCheckForPreexistingResource(ctx context.Context,
 }
 
 func resourceServiceIntegrationWaitUntilActive(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	projectName, integrationID := splitResourceID2(d.Id())
+
+	return serviceIntegrationWaitUntilActive(ctx, m.(*providerMeta).client, projectName, integrationID, d.Timeout(schema.TimeoutCreate))
+}
+
+// serviceIntegrationWaitUntilActive waits for a service integration identified by its plain
+// (not project-prefixed) id to become active
+func serviceIntegrationWaitUntilActive(
+	ctx context.Context,
+	client *aiven.Client,
+	projectName string,
+	integrationID string,
+	timeout time.Duration,
+) error {
 	const (
 		active    = "ACTIVE"
 		notActive = "NOTACTIVE"
 	)
-	client := m.(*aiven.Client)
-
-	projectName, integrationID := splitResourceID2(d.Id())
 
 	stateChangeConf := &resource.StateChangeConf{
 		Pending: []string{notActive},
@@ -343,7 +354,7 @@ func resourceServiceIntegrationWaitUntilActive(ctx context.Context, d *schema.Re
 			return ii, active, nil
 		},
 		Delay:                     2 * time.Second,
-		Timeout:                   d.Timeout(schema.TimeoutCreate),
+		Timeout:                   timeout,
 		MinTimeout:                2 * time.Second,
 		ContinuousTargetOccurence: 5,
 	}
@@ -0,0 +1,343 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var aivenServiceReadReplicaSchema = map[string]*schema.Schema{
+	"project": commonSchemaProjectReference,
+	"source_service_name": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: complex("The name of the service that is replicated.").forceNew().referenced().build(),
+	},
+	"service_name": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: complex("The name of the read replica service.").forceNew().build(),
+	},
+	"plan": {
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Defines what kind of computing resources are allocated for the read replica, the plan is independent of the plan of the source service.",
+	},
+	"cloud_name": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "Defines the cloud provider and region where the read replica is hosted in. Defaults to the cloud of the source service. Changing the value triggers a migration of the read replica.",
+	},
+	"project_vpc_id": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Specifies the VPC the read replica should run in. If the value is not set the read replica is not run inside a VPC.",
+	},
+	"promote": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: complex("Promotes the read replica to a standalone service by removing its replication integration. A promoted service cannot be turned back into a read replica, doing so forces recreation of the resource.").defaultValue(false).build(),
+	},
+	"termination_protection": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Description: "Prevents the read replica from being deleted.",
+	},
+	"service_type": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Aiven internal service type code, the same as the type of the source service.",
+	},
+	"integration_id": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Identifier of the `read_replica` service integration, empty once the read replica has been promoted.",
+	},
+	"state": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Service state. One of `POWEROFF`, `REBALANCING`, `REBUILDING` or `RUNNING`.",
+	},
+	"service_uri": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Sensitive:   true,
+		Description: "URI for connecting to the read replica.",
+	},
+	"service_host": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The hostname of the read replica.",
+	},
+	"service_port": {
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "The port of the read replica.",
+	},
+}
+
+func resourceServiceReadReplica() *schema.Resource {
+	return &schema.Resource{
+		Description:   "The Service Read Replica resource allows the creation, management and promotion of read replicas of Aiven services.",
+		CreateContext: resourceServiceReadReplicaCreate,
+		ReadContext:   resourceServiceReadReplicaRead,
+		UpdateContext: resourceServiceReadReplicaUpdate,
+		DeleteContext: resourceServiceDelete,
+		CustomizeDiff: resourceServiceReadReplicaCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceServiceReadReplicaState,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: aivenServiceReadReplicaSchema,
+	}
+}
+
+func resourceServiceReadReplicaCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	projectName := d.Get("project").(string)
+	sourceServiceName := d.Get("source_service_name").(string)
+	serviceName := d.Get("service_name").(string)
+
+	source, err := client.Services.Get(projectName, sourceServiceName)
+	if err != nil {
+		return diag.Errorf("cannot get source service %s: %s", sourceServiceName, err)
+	}
+
+	cloudName := d.Get("cloud_name").(string)
+	if cloudName == "" {
+		cloudName = source.CloudName
+	}
+
+	_, err = client.Services.Create(
+		projectName,
+		aiven.CreateServiceRequest{
+			Cloud:        cloudName,
+			Plan:         d.Get("plan").(string),
+			ProjectVPCID: serviceProjectVPCID(d),
+			ServiceIntegrations: []aiven.NewServiceIntegration{{
+				IntegrationType: "read_replica",
+				SourceService:   &sourceServiceName,
+				UserConfig:      make(map[string]interface{}),
+			}},
+			ServiceName:           serviceName,
+			ServiceType:           source.Type,
+			TerminationProtection: d.Get("termination_protection").(bool),
+		},
+	)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(buildResourceID(projectName, serviceName))
+
+	if _, err := resourceServiceWait(ctx, d, m, "create"); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("promote").(bool) {
+		if err := serviceReadReplicaPromote(client, projectName, sourceServiceName, serviceName); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceServiceReadReplicaRead(ctx, d, m)
+}
+
+func resourceServiceReadReplicaRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	projectName, serviceName := splitResourceID2(d.Id())
+	service, err := client.Services.Get(projectName, serviceName)
+	if err != nil {
+		return diag.FromErr(resourceReadHandleNotFound(err, d))
+	}
+
+	if err := d.Set("project", projectName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := copyServiceReadReplicaPropertiesFromAPIResponseToTerraform(d, service); err != nil {
+		return diag.FromErr(err)
+	}
+
+	integration := serviceReadReplicaIntegration(service)
+	integrationID := ""
+	if integration != nil {
+		integrationID = integration.ServiceIntegrationID
+		if err := d.Set("source_service_name", *integration.SourceService); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := d.Set("integration_id", integrationID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("promote", integration == nil); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceServiceReadReplicaUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	projectName, serviceName := splitResourceID2(d.Id())
+
+	if d.HasChanges("plan", "cloud_name", "project_vpc_id", "termination_protection") {
+		_, err := client.Services.Update(
+			projectName,
+			serviceName,
+			aiven.UpdateServiceRequest{
+				Cloud:                 d.Get("cloud_name").(string),
+				Plan:                  d.Get("plan").(string),
+				ProjectVPCID:          serviceProjectVPCID(d),
+				Powered:               true,
+				TerminationProtection: d.Get("termination_protection").(bool),
+			},
+		)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if _, err := resourceServiceWait(ctx, d, m, "update"); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("promote") && d.Get("promote").(bool) {
+		err := serviceReadReplicaPromote(client, projectName, d.Get("source_service_name").(string), serviceName)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceServiceReadReplicaRead(ctx, d, m)
+}
+
+// resourceServiceReadReplicaCustomizeDiff recreates a promoted service when it is turned back
+// into a read replica, the API cannot add a replication integration to an existing service
+func resourceServiceReadReplicaCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChange("promote") {
+		return nil
+	}
+
+	if old, _ := d.GetChange("promote"); old.(bool) {
+		return d.ForceNew("promote")
+	}
+
+	return nil
+}
+
+func resourceServiceReadReplicaState(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if len(strings.Split(d.Id(), "/")) != 2 {
+		return nil, fmt.Errorf("invalid identifier %v, expected <project_name>/<service_name>", d.Id())
+	}
+
+	di := resourceServiceReadReplicaRead(ctx, d, m)
+	if di.HasError() {
+		return nil, fmt.Errorf("cannot get service read replica: %v", di)
+	}
+
+	if d.Get("source_service_name").(string) == "" {
+		return nil, fmt.Errorf("service %s is not a read replica", d.Id())
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// serviceReadReplicaIntegration returns the integration replicating data into the service
+func serviceReadReplicaIntegration(service *aiven.Service) *aiven.ServiceIntegration {
+	for _, i := range service.Integrations {
+		if i.IntegrationType == "read_replica" && i.DestinationService != nil && *i.DestinationService == service.Name {
+			return i
+		}
+	}
+
+	return nil
+}
+
+// serviceReadReplicaPromote turns a read replica into a standalone service by deleting its
+// replication integration
+func serviceReadReplicaPromote(client *aiven.Client, projectName, sourceServiceName, serviceName string) error {
+	service, err := client.Services.Get(projectName, serviceName)
+	if err != nil {
+		return err
+	}
+
+	integration := serviceReadReplicaIntegration(service)
+	if integration == nil {
+		log.Printf("[DEBUG] service %s is already promoted", serviceName)
+		return nil
+	}
+
+	log.Printf("[DEBUG] promoting read replica %s of service %s", serviceName, sourceServiceName)
+	if err := client.ServiceIntegrations.Delete(projectName, integration.ServiceIntegrationID); err != nil && !aiven.IsNotFound(err) {
+		return fmt.Errorf("cannot promote read replica %s: %s", serviceName, err)
+	}
+
+	return nil
+}
+
+// serviceProjectVPCID converts the `project_vpc_id` reference to the VPC ID expected by the API
+func serviceProjectVPCID(d *schema.ResourceData) *string {
+	vpcID := d.Get("project_vpc_id").(string)
+	if len(vpcID) == 0 {
+		return nil
+	}
+
+	_, vpcID = splitResourceID2(vpcID)
+	return &vpcID
+}
+
+// copyServiceReadReplicaPropertiesFromAPIResponseToTerraform sets the service level fields
+// shared by read replicas and forks
+func copyServiceReadReplicaPropertiesFromAPIResponseToTerraform(d *schema.ResourceData, service *aiven.Service) error {
+	if err := d.Set("service_name", service.Name); err != nil {
+		return err
+	}
+	if err := d.Set("service_type", service.Type); err != nil {
+		return err
+	}
+	if err := d.Set("plan", service.Plan); err != nil {
+		return err
+	}
+	if err := d.Set("cloud_name", service.CloudName); err != nil {
+		return err
+	}
+	if service.ProjectVPCID != nil {
+		if err := d.Set("project_vpc_id", buildResourceID(d.Get("project").(string), *service.ProjectVPCID)); err != nil {
+			return err
+		}
+	}
+	if err := d.Set("termination_protection", service.TerminationProtection); err != nil {
+		return err
+	}
+	if err := d.Set("state", service.State); err != nil {
+		return err
+	}
+	if err := d.Set("service_uri", service.URI); err != nil {
+		return err
+	}
+	if err := d.Set("service_host", service.URIParams["host"]); err != nil {
+		return err
+	}
+
+	port, _ := strconv.ParseInt(service.URIParams["port"], 10, 32)
+	return d.Set("service_port", port)
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAivenServiceReadReplica_basic(t *testing.T) {
+	resourceName := "aiven_service_read_replica.foo"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenServiceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceReadReplicaResource(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "project", os.Getenv("AIVEN_PROJECT_NAME")),
+					resource.TestCheckResourceAttr(resourceName, "source_service_name", fmt.Sprintf("test-acc-sr-%s", rName)),
+					resource.TestCheckResourceAttr(resourceName, "service_type", "pg"),
+					resource.TestCheckResourceAttr(resourceName, "cloud_name", "google-europe-west1"),
+					resource.TestCheckResourceAttr(resourceName, "promote", "false"),
+					resource.TestCheckResourceAttrSet(resourceName, "integration_id"),
+				),
+			},
+			{
+				Config: testAccServiceReadReplicaResource(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "promote", "true"),
+					resource.TestCheckResourceAttr(resourceName, "integration_id", ""),
+				),
+			},
+		},
+	})
+}
+
+func testAccServiceReadReplicaResource(name string, promote bool) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_pg" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-4"
+			service_name = "test-acc-sr-%s"
+		}
+
+		resource "aiven_service_read_replica" "foo" {
+			project = data.aiven_project.foo.project
+			source_service_name = aiven_pg.bar.service_name
+			service_name = "test-acc-sr-replica-%s"
+			plan = "startup-4"
+			promote = %t
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name, name, promote)
+}
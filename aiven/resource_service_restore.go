@@ -0,0 +1,15 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceServiceRestore is the fork resource under the name used for disaster recovery, a
+// restore is a fork of the service from a chosen backup or point in time
+func resourceServiceRestore() *schema.Resource {
+	r := resourceServiceFork()
+	r.Description = "The Service Restore resource creates a new Aiven service from a backup, or a point in time, of an existing service. It is the same as the `aiven_service_fork` resource."
+
+	return r
+}
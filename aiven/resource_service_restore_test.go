@@ -0,0 +1,61 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAivenServiceRestore_basic(t *testing.T) {
+	resourceName := "aiven_service_restore.foo"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenServiceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceRestoreResource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "project", os.Getenv("AIVEN_PROJECT_NAME")),
+					resource.TestCheckResourceAttr(resourceName, "source_service_name", fmt.Sprintf("test-acc-sr-%s", rName)),
+					resource.TestCheckResourceAttr(resourceName, "service_type", "pg"),
+					resource.TestCheckResourceAttr(resourceName, "state", "RUNNING"),
+				),
+			},
+		},
+	})
+}
+
+func testAccServiceRestoreResource(name string) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_pg" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-4"
+			service_name = "test-acc-sr-%s"
+		}
+
+		data "aiven_service_backups" "backups" {
+			project = aiven_pg.bar.project
+			service_name = aiven_pg.bar.service_name
+		}
+
+		resource "aiven_service_restore" "foo" {
+			project = data.aiven_project.foo.project
+			source_service_name = aiven_pg.bar.service_name
+			service_name = "test-acc-sr-restore-%s"
+			plan = "startup-4"
+			recovery_target_time = data.aiven_service_backups.backups.latest_backup_time
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name, name)
+}
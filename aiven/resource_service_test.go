@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -79,6 +80,145 @@ func sweepServices(region string) error {
 	return nil
 }
 
+func TestAccAivenService_powered(t *testing.T) {
+	resourceName := "aiven_pg.bar"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenServiceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServicePoweredResource(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "powered", "true"),
+					resource.TestCheckResourceAttr(resourceName, "state", "RUNNING"),
+				),
+			},
+			{
+				Config: testAccServicePoweredResource(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "powered", "false"),
+					resource.TestCheckResourceAttr(resourceName, "state", "POWEROFF"),
+					resource.TestCheckResourceAttr("aiven_database.foo", "database_name", fmt.Sprintf("test-acc-db-%s", rName)),
+				),
+			},
+			{
+				Config: testAccServicePoweredResource(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "powered", "true"),
+					resource.TestCheckResourceAttr(resourceName, "state", "RUNNING"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAivenService_createPoweredOff(t *testing.T) {
+	resourceName := "aiven_pg.bar"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenServiceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceCreatePoweredOffResource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "powered", "false"),
+					resource.TestCheckResourceAttr(resourceName, "additional_disk_space", "10240"),
+					resource.TestCheckResourceAttr(resourceName, "state", "POWEROFF"),
+				),
+			},
+		},
+	})
+}
+
+func testAccServiceCreatePoweredOffResource(name string) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_pg" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "business-4"
+			service_name = "test-acc-sr-%s"
+			additional_disk_space = 10240
+			powered = false
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name)
+}
+
+func testAccServicePoweredResource(name string, powered bool) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_pg" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-4"
+			service_name = "test-acc-sr-%s"
+			powered = %t
+		}
+
+		resource "aiven_database" "foo" {
+			project = aiven_pg.bar.project
+			service_name = aiven_pg.bar.service_name
+			database_name = "test-acc-db-%s"
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name, powered, name)
+}
+
+func TestAccAivenService_waitForMigration(t *testing.T) {
+	resourceName := "aiven_pg.bar"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenServiceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceWaitForMigrationResource(rName, "startup-4"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "plan", "startup-4"),
+					resource.TestCheckResourceAttr(resourceName, "state", "RUNNING"),
+				),
+			},
+			{
+				Config: testAccServiceWaitForMigrationResource(rName, "startup-8"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "plan", "startup-8"),
+					resource.TestCheckResourceAttr(resourceName, "wait_for_migration", "true"),
+					resource.TestCheckResourceAttr(resourceName, "state", "RUNNING"),
+				),
+			},
+		},
+	})
+}
+
+func testAccServiceWaitForMigrationResource(name, plan string) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_pg" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "%s"
+			service_name = "test-acc-sr-%s"
+			wait_for_migration = true
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), plan, name)
+}
+
 func testAccCheckAivenServiceCommonAttributes(n string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		r := s.RootModule().Resources[n]
@@ -152,7 +292,7 @@ func testAccCheckAivenServiceCommonAttributes(n string) resource.TestCheckFunc {
 }
 
 func testAccCheckAivenServiceResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 	// loop through the resources in state, verifying each service is destroyed
 	for _, rs := range s.RootModule().Resources {
 		var r []string
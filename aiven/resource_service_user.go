@@ -4,8 +4,17 @@ package aiven
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
 	"fmt"
+	"log"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/aiven/aiven-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -27,9 +36,69 @@ var aivenServiceUserSchema = map[string]*schema.Schema{
 		Type:             schema.TypeString,
 		Sensitive:        true,
 		Optional:         true,
-		DiffSuppressFunc: emptyObjectDiffSuppressFunc,
+		Computed:         true,
+		DiffSuppressFunc: serviceUserPasswordDiffSuppressFunc,
 		Description:      "The password of the service user ( not applicable for all services ).",
 	},
+	"password_write_only": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "When enabled the password is not stored in the Terraform state, only its hash in `password_hash` is kept to detect changes. The value of `password` is then empty in the state.",
+	},
+	"password_hash": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Salted hash of the password of the service user in the form `<salt>$<hash>`, the hash is the hex encoded HMAC-SHA256 of the password keyed with the hex encoded random salt. A new salt is generated when the password changes.",
+	},
+	"ignore_password_drift": {
+		Type:          schema.TypeBool,
+		Optional:      true,
+		Default:       false,
+		ConflictsWith: []string{"password_write_only"},
+		Description:   "When enabled a password changed outside of Terraform is not reported as a change of the `password` field and is not reset to the configured value, `password_hash` and `password_updated_at` still follow the password of the service user. It cannot be used with `password_write_only` as the configured password is then only known from `password_hash`.",
+	},
+	"rotation": {
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: []string{"password"},
+		Description:   "Regenerates the credentials of the service user (the password and, where applicable, the access certificate and key) when any of the configured conditions is met.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"keepers": {
+					Type:        schema.TypeMap,
+					Optional:    true,
+					Description: "Arbitrary map of values that, when changed, will trigger the credentials to be regenerated.",
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"rotation_days": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description:  "Number of days after which the credentials are regenerated, counted from `password_updated_at`.",
+				},
+				"certificate_renew_before_days": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description:  "Regenerates the credentials when the access certificate expires in less than the given number of days.",
+				},
+			},
+		},
+	},
+	"password_updated_at": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Time in RFC3339 format when the password was last set, regenerated or detected as changed by Terraform.",
+	},
+	"access_cert_not_after": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Expiration time of the access certificate in RFC3339 format, if applicable for the service in question.",
+	},
 	"redis_acl_categories": {
 		Type:         schema.TypeList,
 		Optional:     true,
@@ -37,7 +106,8 @@ var aivenServiceUserSchema = map[string]*schema.Schema{
 		RequiredWith: []string{"redis_acl_commands", "redis_acl_keys"},
 		Description:  complex("Redis specific field, defines command category rules.").requiredWith("redis_acl_commands", "redis_acl_keys").forceNew().build(),
 		Elem: &schema.Schema{
-			Type: schema.TypeString,
+			Type:         schema.TypeString,
+			ValidateFunc: validateRedisACLCategory,
 		},
 	},
 	"redis_acl_commands": {
@@ -47,7 +117,8 @@ var aivenServiceUserSchema = map[string]*schema.Schema{
 		RequiredWith: []string{"redis_acl_categories", "redis_acl_keys"},
 		Description:  complex("Redis specific field, defines rules for individual commands.").requiredWith("redis_acl_categories", "redis_acl_keys").forceNew().build(),
 		Elem: &schema.Schema{
-			Type: schema.TypeString,
+			Type:         schema.TypeString,
+			ValidateFunc: validateRedisACLCommand,
 		},
 	},
 	"redis_acl_keys": {
@@ -57,7 +128,8 @@ var aivenServiceUserSchema = map[string]*schema.Schema{
 		RequiredWith: []string{"redis_acl_categories", "redis_acl_commands"},
 		Description:  complex("Redis specific field, defines key access rules.").requiredWith("redis_acl_categories", "redis_acl_keys").forceNew().build(),
 		Elem: &schema.Schema{
-			Type: schema.TypeString,
+			Type:         schema.TypeString,
+			ValidateFunc: validateRedisACLPattern,
 		},
 	},
 	"redis_acl_channels": {
@@ -66,9 +138,17 @@ var aivenServiceUserSchema = map[string]*schema.Schema{
 		ForceNew:    true,
 		Description: complex("Redis specific field, defines the permitted pub/sub channel patterns.").forceNew().build(),
 		Elem: &schema.Schema{
-			Type: schema.TypeString,
+			Type:         schema.TypeString,
+			ValidateFunc: validateRedisACLPattern,
 		},
 	},
+	"pg_allow_replication": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Computed:    true,
+		Description: "PostgreSQL specific field, allows the user to use the replication protocol. The value of the service is kept when not set.",
+	},
+	"access_control": aivenServiceUserAccessControlSchema,
 	"authentication": {
 		Type:             schema.TypeString,
 		Optional:         true,
@@ -97,11 +177,12 @@ var aivenServiceUserSchema = map[string]*schema.Schema{
 
 func resourceServiceUser() *schema.Resource {
 	return &schema.Resource{
-		Description:   "The Service User resource allows the creation and management of Aiven Service Users.",
+		Description:   "The Service User resource allows the creation and management of Aiven Service Users. Database, table and role grants of PostgreSQL, MySQL and Cassandra users are managed with the `access_control` block over a connection to the service.",
 		CreateContext: resourceServiceUserCreate,
 		UpdateContext: resourceServiceUserUpdate,
 		ReadContext:   resourceServiceUserRead,
 		DeleteContext: resourceServiceUserDelete,
+		CustomizeDiff: resourceServiceUserCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceUserState,
 		},
@@ -111,7 +192,7 @@ func resourceServiceUser() *schema.Resource {
 }
 
 func resourceServiceUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
@@ -122,10 +203,11 @@ func resourceServiceUserCreate(ctx context.Context, d *schema.ResourceData, m in
 		aiven.CreateServiceUserRequest{
 			Username: username,
 			AccessControl: &aiven.AccessControl{
-				RedisACLCategories: flattenToString(d.Get("redis_acl_categories").([]interface{})),
-				RedisACLCommands:   flattenToString(d.Get("redis_acl_commands").([]interface{})),
-				RedisACLKeys:       flattenToString(d.Get("redis_acl_keys").([]interface{})),
-				RedisACLChannels:   flattenToString(d.Get("redis_acl_channels").([]interface{})),
+				RedisACLCategories:       flattenToString(d.Get("redis_acl_categories").([]interface{})),
+				RedisACLCommands:         flattenToString(d.Get("redis_acl_commands").([]interface{})),
+				RedisACLKeys:             flattenToString(d.Get("redis_acl_keys").([]interface{})),
+				RedisACLChannels:         flattenToString(d.Get("redis_acl_channels").([]interface{})),
+				PostgresAllowReplication: serviceUserPGAllowReplication(d),
 			},
 		},
 	)
@@ -146,26 +228,196 @@ func resourceServiceUserCreate(ctx context.Context, d *schema.ResourceData, m in
 
 	d.SetId(buildResourceID(projectName, serviceName, username))
 
+	if err := d.Set("password_updated_at", time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := resourceServiceUserApplyAccessControl(ctx, d, m, []interface{}{}, d.Get("access_control")); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return resourceServiceUserRead(ctx, d, m)
 }
 
 func resourceServiceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, serviceName, username := splitResourceID3(d.Id())
 
-	_, err := client.ServiceUsers.Update(projectName, serviceName, username,
-		aiven.ModifyServiceUserRequest{
+	// without a new password the API regenerates the credentials of the user
+	passwordUpdatedAt, _ := d.GetChange("password_updated_at")
+	certNotAfter, _ := d.GetChange("access_cert_not_after")
+	rotate := d.HasChange("rotation.0.keepers") ||
+		serviceUserRotationDue(d.Get("rotation").([]interface{}), passwordUpdatedAt.(string), certNotAfter.(string), time.Now())
+	if rotate {
+		log.Printf("[DEBUG] regenerating credentials of service user %s", d.Id())
+	}
+
+	if rotate || d.HasChanges("password", "authentication") {
+		// an authentication change alone keeps the current password of the user, it is read
+		// from the service as the state does not have it with a write-only password
+		var currentPassword string
+		if !rotate && !d.HasChange("password") {
+			user, err := client.ServiceUsers.Get(projectName, serviceName, username)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			currentPassword = user.Password
+		}
+
+		newPassword, err := serviceUserNewPassword(rotate, d.HasChange("password"), d.Get("password").(string), currentPassword)
+		if err != nil {
+			return diag.Errorf("cannot update service user %s: %s", d.Id(), err)
+		}
+
+		req := aiven.ModifyServiceUserRequest{
 			Authentication: optionalStringPointer(d, "authentication"),
-			NewPassword:    optionalStringPointer(d, "password"),
-		})
-	if err != nil {
-		return diag.FromErr(err)
+			NewPassword:    newPassword,
+		}
+		if _, err := client.ServiceUsers.Update(projectName, serviceName, username, req); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// access control changes are a separate operation that does not touch the credentials
+	if d.HasChange("pg_allow_replication") {
+		operation := aiven.UpdateOperationSetAccessControl
+		allowReplication := d.Get("pg_allow_replication").(bool)
+		_, err := client.ServiceUsers.Update(projectName, serviceName, username,
+			aiven.ModifyServiceUserRequest{
+				Operation: &operation,
+				AccessControl: &aiven.AccessControl{
+					PostgresAllowReplication: &allowReplication,
+				},
+			})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("access_control") {
+		old, new := d.GetChange("access_control")
+		if err := resourceServiceUserApplyAccessControl(ctx, d, m, old, new); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if rotate || d.HasChange("password") {
+		if err := d.Set("password_updated_at", time.Now().UTC().Format(time.RFC3339)); err != nil {
+			return diag.FromErr(err)
+		}
+		// the password was changed by Terraform, let the read accept it even when drift is ignored
+		if err := d.Set("password_hash", ""); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	return resourceServiceUserRead(ctx, d, m)
 }
 
+// serviceUserNewPassword returns the password sent with a credentials update of a service user,
+// nil regenerates the credentials. The configured password is only sent when it changed, other
+// updates keep the current password of the user.
+func serviceUserNewPassword(rotate, passwordChanged bool, password, currentPassword string) (*string, error) {
+	switch {
+	case rotate:
+		return nil, nil
+	case passwordChanged && password != "":
+		return &password, nil
+	case currentPassword != "":
+		return &currentPassword, nil
+	}
+
+	return nil, fmt.Errorf("the current password of the user is not known, it would be regenerated")
+}
+
+// serviceUserPGAllowReplication returns the pg_allow_replication value only when it is set
+// in the configuration, so that other service types do not receive the field
+func serviceUserPGAllowReplication(d *schema.ResourceData) *bool {
+	v, ok := d.GetOkExists("pg_allow_replication") // nolint:staticcheck
+	if !ok {
+		return nil
+	}
+	allowReplication := v.(bool)
+	return &allowReplication
+}
+
+// resourceServiceUserCustomizeDiff plans the credentials as regenerated when the keepers of the
+// rotation block change or one of its time based conditions is met. A changed password also
+// changes its hash.
+func resourceServiceUserCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	if d.HasChange("password") {
+		if err := d.SetNewComputed("password_hash"); err != nil {
+			return err
+		}
+	}
+
+	rotation := d.Get("rotation").([]interface{})
+	if !d.HasChange("rotation.0.keepers") &&
+		!serviceUserRotationDue(rotation, d.Get("password_updated_at").(string), d.Get("access_cert_not_after").(string), time.Now()) {
+		return nil
+	}
+
+	for _, k := range []string{"password", "password_hash", "password_updated_at", "access_cert", "access_key", "access_cert_not_after"} {
+		if err := d.SetNewComputed(k); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// serviceUserRotationDue checks the time based conditions of a rotation block
+func serviceUserRotationDue(rotation []interface{}, passwordUpdatedAt, certNotAfter string, now time.Time) bool {
+	if len(rotation) == 0 || rotation[0] == nil {
+		return false
+	}
+	r := rotation[0].(map[string]interface{})
+
+	if days, ok := r["rotation_days"].(int); ok && days > 0 && passwordUpdatedAt != "" {
+		updatedAt, err := time.Parse(time.RFC3339, passwordUpdatedAt)
+		if err == nil && !now.Before(updatedAt.AddDate(0, 0, days)) {
+			return true
+		}
+	}
+
+	if days, ok := r["certificate_renew_before_days"].(int); ok && days > 0 && certNotAfter != "" {
+		notAfter, err := time.Parse(time.RFC3339, certNotAfter)
+		if err == nil && !now.Before(notAfter.AddDate(0, 0, -days)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// serviceUserAccessCertNotAfter returns the expiration time of the user access certificate,
+// falling back to parsing the certificate when the API does not report it
+func serviceUserAccessCertNotAfter(user *aiven.ServiceUser) string {
+	if user.AccessCertNotValidAfterTime != "" {
+		if t, err := time.Parse(time.RFC3339, user.AccessCertNotValidAfterTime); err == nil {
+			return t.UTC().Format(time.RFC3339)
+		}
+	}
+
+	block, _ := pem.Decode([]byte(user.AccessCert))
+	if block == nil {
+		return ""
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		log.Printf("[DEBUG] cannot parse service user %s access certificate: %s", user.Username, err)
+		return ""
+	}
+
+	return cert.NotAfter.UTC().Format(time.RFC3339)
+}
+
 func copyServiceUserPropertiesFromAPIResponseToTerraform(
 	d *schema.ResourceData,
 	user *aiven.ServiceUser,
@@ -181,7 +433,7 @@ func copyServiceUserPropertiesFromAPIResponseToTerraform(
 	if err := d.Set("username", user.Username); err != nil {
 		return err
 	}
-	if err := d.Set("password", user.Password); err != nil {
+	if err := copyServiceUserPasswordFromAPIResponseToTerraform(d, user); err != nil {
 		return err
 	}
 	if err := d.Set("type", user.Type); err != nil {
@@ -193,6 +445,9 @@ func copyServiceUserPropertiesFromAPIResponseToTerraform(
 	if err := d.Set("access_key", user.AccessKey); err != nil {
 		return err
 	}
+	if err := d.Set("access_cert_not_after", serviceUserAccessCertNotAfter(user)); err != nil {
+		return err
+	}
 	if err := d.Set("redis_acl_keys", user.AccessControl.RedisACLKeys); err != nil {
 		return err
 	}
@@ -205,17 +460,111 @@ func copyServiceUserPropertiesFromAPIResponseToTerraform(
 	if err := d.Set("redis_acl_channels", user.AccessControl.RedisACLChannels); err != nil {
 		return err
 	}
+	if user.AccessControl.PostgresAllowReplication != nil {
+		if err := d.Set("pg_allow_replication", *user.AccessControl.PostgresAllowReplication); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
 
+// copyServiceUserPasswordFromAPIResponseToTerraform detects password changes by comparing
+// hashes so that it works also when the password itself is not kept in the state
+func copyServiceUserPasswordFromAPIResponseToTerraform(d *schema.ResourceData, user *aiven.ServiceUser) error {
+	// the known hash is kept while it matches so that the salt does not change on every read
+	hash := d.Get("password_hash").(string)
+	changed := hash != "" && !serviceUserPasswordHashMatches(user.Password, hash)
+	if hash == "" || changed {
+		var err error
+		if hash, err = serviceUserPasswordHash(user.Password); err != nil {
+			return err
+		}
+	}
+
+	// a password changed outside of Terraform counts as an update of the password
+	if changed || d.Get("password_updated_at").(string) == "" {
+		if err := d.Set("password_updated_at", time.Now().UTC().Format(time.RFC3339)); err != nil {
+			return err
+		}
+	}
+	if err := d.Set("password_hash", hash); err != nil {
+		return err
+	}
+
+	// the password in the state is kept so that it does not differ from the configured one
+	if changed && d.Get("ignore_password_drift").(bool) {
+		log.Printf("[WARN] password of service user %s was changed outside of Terraform, ignoring", user.Username)
+		return nil
+	}
+
+	password := user.Password
+	if d.Get("password_write_only").(bool) {
+		password = ""
+	}
+
+	return d.Set("password", password)
+}
+
+// serviceUserPasswordHashSaltSize is the size in bytes of the random salt of a password hash
+const serviceUserPasswordHashSaltSize = 16
+
+// serviceUserPasswordHash returns a hash of a password with a new random salt in the form
+// `<salt>$<hash>`, see serviceUserPasswordHashWithSalt
+func serviceUserPasswordHash(password string) (string, error) {
+	if password == "" {
+		return "", nil
+	}
+
+	salt := make([]byte, serviceUserPasswordHashSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("cannot generate password hash salt: %s", err)
+	}
+
+	return serviceUserPasswordHashWithSalt(password, hex.EncodeToString(salt)), nil
+}
+
+// serviceUserPasswordHashWithSalt returns the hex encoded HMAC-SHA256 of a password keyed with
+// the given hex encoded salt, prefixed with the salt
+func serviceUserPasswordHashWithSalt(password, salt string) string {
+	mac := hmac.New(sha256.New, []byte(salt))
+	mac.Write([]byte(password))
+
+	return salt + "$" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// serviceUserPasswordHashMatches checks a password against a hash of serviceUserPasswordHash
+func serviceUserPasswordHashMatches(password, hash string) bool {
+	if password == "" || hash == "" {
+		return password == "" && hash == ""
+	}
+
+	i := strings.Index(hash, "$")
+	if i < 0 {
+		return false
+	}
+
+	return hmac.Equal([]byte(serviceUserPasswordHashWithSalt(password, hash[:i])), []byte(hash))
+}
+
+// serviceUserPasswordDiffSuppressFunc in addition to the empty value handling suppresses
+// the difference between a configured password and a write-only password with a matching hash
+func serviceUserPasswordDiffSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
+	if emptyObjectDiffSuppressFunc(k, old, new, d) {
+		return true
+	}
+
+	return old == "" && d.Get("password_write_only").(bool) &&
+		serviceUserPasswordHashMatches(new, d.Get("password_hash").(string))
+}
+
 func resourceServiceUserRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, serviceName, username := splitResourceID3(d.Id())
 	user, err := client.ServiceUsers.Get(projectName, serviceName, username)
 	if err != nil {
-		return diag.FromErr(resourceReadHandleNotFound(err, d))
+		return diag.FromErr(resourceReadHandleServicePoweredOff(err, d, client, projectName, serviceName))
 	}
 
 	err = copyServiceUserPropertiesFromAPIResponseToTerraform(d, user, projectName, serviceName)
@@ -226,8 +575,12 @@ func resourceServiceUserRead(_ context.Context, d *schema.ResourceData, m interf
 	return nil
 }
 
-func resourceServiceUserDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+func resourceServiceUserDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	if err := resourceServiceUserRevokeAccessControl(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
 
 	projectName, serviceName, username := splitResourceID3(d.Id())
 	err := client.ServiceUsers.Delete(projectName, serviceName, username)
@@ -239,7 +592,7 @@ func resourceServiceUserDelete(_ context.Context, d *schema.ResourceData, m inte
 }
 
 func resourceServiceUserState(_ context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	if len(strings.Split(d.Id(), "/")) != 3 {
 		return nil, fmt.Errorf("invalid identifier %v, expected <project_name>/<service_name>/<username>", d.Id())
@@ -258,3 +611,64 @@ func resourceServiceUserState(_ context.Context, d *schema.ResourceData, m inter
 
 	return []*schema.ResourceData{d}, nil
 }
+
+var (
+	redisACLCategories = []string{
+		"all", "keyspace", "read", "write", "set", "sortedset", "list", "hash", "string", "bitmap",
+		"hyperloglog", "geo", "stream", "pubsub", "admin", "fast", "slow", "blocking", "dangerous",
+		"connection", "transaction", "scripting",
+	}
+
+	redisACLCategoryRegExp = regexp.MustCompile(`^[+-]@([a-z]+)$`)
+	redisACLCommandRegExp  = regexp.MustCompile(`^[+-][a-zA-Z0-9_.]+(\|[a-zA-Z0-9_.-]+)?$`)
+)
+
+// validateRedisACLCategory validates a Redis ACL category rule such as `+@read` or `-@dangerous`
+func validateRedisACLCategory(v interface{}, k string) (ws []string, errors []error) {
+	rule := v.(string)
+
+	match := redisACLCategoryRegExp.FindStringSubmatch(rule)
+	if match == nil {
+		errors = append(errors, fmt.Errorf("%q: invalid Redis ACL category rule %q, expected +@<category> or -@<category>", k, rule))
+		return
+	}
+
+	for _, c := range redisACLCategories {
+		if c == match[1] {
+			return
+		}
+	}
+
+	errors = append(errors, fmt.Errorf("%q: unknown Redis ACL category %q, expected one of %s",
+		k, match[1], strings.Join(redisACLCategories, ", ")))
+	return
+}
+
+// validateRedisACLCommand validates a Redis ACL command rule such as `+get`, `-flushall` or
+// `+config|get`
+func validateRedisACLCommand(v interface{}, k string) (ws []string, errors []error) {
+	rule := v.(string)
+
+	if !redisACLCommandRegExp.MatchString(rule) {
+		errors = append(errors, fmt.Errorf("%q: invalid Redis ACL command rule %q, expected +<command>, -<command> "+
+			"or +<command>|<subcommand>", k, rule))
+	}
+
+	return
+}
+
+// validateRedisACLPattern validates a Redis ACL key or channel glob-style pattern
+func validateRedisACLPattern(v interface{}, k string) (ws []string, errors []error) {
+	pattern := v.(string)
+
+	switch {
+	case pattern == "":
+		errors = append(errors, fmt.Errorf("%q: Redis ACL pattern cannot be empty", k))
+	case strings.ContainsAny(pattern, " \t\r\n"):
+		errors = append(errors, fmt.Errorf("%q: Redis ACL pattern %q cannot contain whitespace", k, pattern))
+	case strings.HasPrefix(pattern, "~") || strings.HasPrefix(pattern, "&"):
+		errors = append(errors, fmt.Errorf("%q: Redis ACL pattern %q should be given without the `~` or `&` prefix", k, pattern))
+	}
+
+	return
+}
@@ -3,14 +3,18 @@
 package aiven
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
 	"log"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/aiven/aiven-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -47,8 +51,122 @@ func TestAccAivenServiceUser_basic(t *testing.T) {
 	})
 }
 
+func TestAccAivenServiceUser_rotation(t *testing.T) {
+	resourceName := "aiven_service_user.foo"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenServiceUserResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceUserRotationResource(rName, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "rotation.0.keepers.generation", "1"),
+					resource.TestCheckResourceAttrSet(resourceName, "password"),
+					resource.TestCheckResourceAttrSet(resourceName, "password_updated_at"),
+					resource.TestCheckResourceAttrSet(resourceName, "access_cert_not_after"),
+				),
+			},
+			{
+				Config: testAccServiceUserRotationResource(rName, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "rotation.0.keepers.generation", "2"),
+					resource.TestCheckResourceAttrSet(resourceName, "password"),
+					resource.TestCheckResourceAttrSet(resourceName, "access_cert_not_after"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAivenServiceUser_writeOnlyPassword(t *testing.T) {
+	resourceName := "aiven_service_user.foo"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenServiceUserResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceUserWriteOnlyPasswordResource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "password_write_only", "true"),
+					resource.TestCheckResourceAttr(resourceName, "password", ""),
+					testAccCheckAivenServiceUserPasswordHash(resourceName, "Test$1234"),
+				),
+			},
+			{
+				Config:   testAccServiceUserWriteOnlyPasswordResource(rName),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+// TestAccAivenServiceUser_authentication checks that changing only the authentication of a
+// user with a write-only password keeps the password
+func TestAccAivenServiceUser_authentication(t *testing.T) {
+	resourceName := "aiven_service_user.foo"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenServiceUserResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceUserAuthenticationResource(rName, "caching_sha2_password"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "authentication", "caching_sha2_password"),
+					testAccCheckAivenServiceUserPasswordHash(resourceName, "Test$1234"),
+				),
+			},
+			{
+				Config: testAccServiceUserAuthenticationResource(rName, "mysql_native_password"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "authentication", "mysql_native_password"),
+					testAccCheckAivenServiceUserPasswordHash(resourceName, "Test$1234"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccAivenServiceUser_accessControl checks that the grants of the access_control block
+// are applied to and revoked from a PostgreSQL user
+func TestAccAivenServiceUser_accessControl(t *testing.T) {
+	resourceName := "aiven_service_user.foo"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenServiceUserResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceUserAccessControlResource(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "access_control.0.read_only_databases.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "access_control.0.roles.#", "1"),
+					testAccCheckAivenServiceUserPGPrivileges(resourceName, "user-role-"+rName, true),
+				),
+			},
+			{
+				Config: testAccServiceUserAccessControlResource(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "access_control.#", "0"),
+					testAccCheckAivenServiceUserPGPrivileges(resourceName, "user-role-"+rName, false),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckAivenServiceUserResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each aiven_service_user is destroyed
 	for _, rs := range s.RootModule().Resources {
@@ -142,6 +260,176 @@ func testAccServiceUserNewPasswordResource(name string) string {
 		`, os.Getenv("AIVEN_PROJECT_NAME"), name, name)
 }
 
+func testAccServiceUserRotationResource(name, generation string) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_kafka" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-2"
+			service_name = "test-acc-sr-%s"
+		}
+
+		resource "aiven_service_user" "foo" {
+			service_name = aiven_kafka.bar.service_name
+			project = data.aiven_project.foo.project
+			username = "user-%s"
+
+			rotation {
+				keepers = {
+					generation = "%s"
+				}
+				rotation_days = 30
+				certificate_renew_before_days = 14
+			}
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name, name, generation)
+}
+
+func testAccServiceUserWriteOnlyPasswordResource(name string) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_pg" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-4"
+			service_name = "test-acc-sr-%s"
+		}
+
+		resource "aiven_service_user" "foo" {
+			service_name = aiven_pg.bar.service_name
+			project = data.aiven_project.foo.project
+			username = "user-%s"
+			password = "Test$1234"
+			password_write_only = true
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name, name)
+}
+
+func testAccServiceUserAuthenticationResource(name, authentication string) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_mysql" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-4"
+			service_name = "test-acc-sr-%s"
+		}
+
+		resource "aiven_service_user" "foo" {
+			service_name = aiven_mysql.bar.service_name
+			project = data.aiven_project.foo.project
+			username = "user-%s"
+			password = "Test$1234"
+			password_write_only = true
+			authentication = "%s"
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name, name, authentication)
+}
+
+func testAccServiceUserAccessControlResource(name string, accessControl bool) string {
+	block := ""
+	if accessControl {
+		block = `
+			access_control {
+				grant {
+					database = "defaultdb"
+					privileges = ["INSERT", "UPDATE"]
+				}
+				roles = [aiven_service_user.role.username]
+				read_only_databases = ["defaultdb"]
+			}`
+	}
+
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_pg" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-4"
+			service_name = "test-acc-sr-%s"
+		}
+
+		resource "aiven_service_user" "role" {
+			service_name = aiven_pg.bar.service_name
+			project = data.aiven_project.foo.project
+			username = "user-role-%s"
+		}
+
+		resource "aiven_service_user" "foo" {
+			service_name = aiven_pg.bar.service_name
+			project = data.aiven_project.foo.project
+			username = "user-%s"
+			%s
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name, name, name, block)
+}
+
+// testAccCheckAivenServiceUserPGPrivileges checks the default privileges of a PostgreSQL user
+// in defaultdb, which only the read-only grant gives, and its membership of a role
+func testAccCheckAivenServiceUserPGPrivileges(n, role string, granted bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		r, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("resource %s not found", n)
+		}
+
+		projectName, serviceName, username := splitResourceID3(r.Primary.ID)
+		conn, err := getServiceConnection(testAccProvider.Meta().(*providerMeta).client, projectName, serviceName)
+		if err != nil {
+			return err
+		}
+
+		return withServicePostgres(conn, "defaultdb", func(db *sql.DB) error {
+			var defaultPrivileges, member bool
+			err := db.QueryRow(`
+				SELECT
+					EXISTS (SELECT 1 FROM pg_default_acl, aclexplode(defaclacl) acl
+						WHERE acl.grantee = (SELECT oid FROM pg_roles WHERE rolname = $1)),
+					pg_has_role($1, $2, 'MEMBER')`, username, role,
+			).Scan(&defaultPrivileges, &member)
+			if err != nil {
+				return err
+			}
+
+			if defaultPrivileges != granted || member != granted {
+				return fmt.Errorf("user %s has default privileges %t and role membership %t, expected %t",
+					username, defaultPrivileges, member, granted)
+			}
+			return nil
+		})
+	}
+}
+
+// testAccCheckAivenServiceUserPasswordHash checks that the password hash of a service user
+// matches the given password
+func testAccCheckAivenServiceUserPasswordHash(n, password string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		r, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("resource %s not found", n)
+		}
+
+		if hash := r.Primary.Attributes["password_hash"]; !serviceUserPasswordHashMatches(password, hash) {
+			return fmt.Errorf("password_hash %q does not match the password", hash)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckAivenServiceUserAttributes(n string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		r := s.RootModule().Resources[n]
@@ -172,3 +460,359 @@ func testAccCheckAivenServiceUserAttributes(n string) resource.TestCheckFunc {
 		return nil
 	}
 }
+
+func Test_serviceUserRotationDue(t *testing.T) {
+	now := time.Date(2021, 11, 20, 12, 0, 0, 0, time.UTC)
+	rotation := []interface{}{
+		map[string]interface{}{
+			"keepers":                       map[string]interface{}{},
+			"rotation_days":                 30,
+			"certificate_renew_before_days": 14,
+		},
+	}
+
+	tests := []struct {
+		name              string
+		rotation          []interface{}
+		passwordUpdatedAt string
+		certNotAfter      string
+		want              bool
+	}{
+		{
+			"no-rotation",
+			nil,
+			"2020-01-01T00:00:00Z",
+			"2021-11-21T00:00:00Z",
+			false,
+		},
+		{
+			"fresh-credentials",
+			rotation,
+			"2021-11-01T00:00:00Z",
+			"2022-11-01T00:00:00Z",
+			false,
+		},
+		{
+			"password-expired",
+			rotation,
+			"2021-10-21T12:00:00Z",
+			"2022-11-01T00:00:00Z",
+			true,
+		},
+		{
+			"certificate-expiring",
+			rotation,
+			"2021-11-01T00:00:00Z",
+			"2021-12-01T00:00:00Z",
+			true,
+		},
+		{
+			"unknown-times",
+			rotation,
+			"",
+			"",
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := serviceUserRotationDue(tt.rotation, tt.passwordUpdatedAt, tt.certNotAfter, now); got != tt.want {
+				t.Errorf("serviceUserRotationDue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_resourceServiceUserCustomizeDiff(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "test-project/test-service/test-user",
+		Attributes: map[string]string{
+			"id":                            "test-project/test-service/test-user",
+			"project":                       "test-project",
+			"service_name":                  "test-service",
+			"username":                      "test-user",
+			"password":                      "secret",
+			"password_hash":                 serviceUserPasswordHashWithSalt("secret", "00"),
+			"password_updated_at":           time.Now().UTC().Format(time.RFC3339),
+			"ignore_password_drift":         "false",
+			"rotation.#":                    "1",
+			"rotation.0.keepers.%":          "1",
+			"rotation.0.keepers.generation": "1",
+			"rotation.0.rotation_days":      "30",
+			"rotation.0.certificate_renew_before_days": "0",
+		},
+	}
+	config := func(generation string) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"project":      "test-project",
+			"service_name": "test-service",
+			"username":     "test-user",
+			"rotation": []interface{}{
+				map[string]interface{}{
+					"keepers":       map[string]interface{}{"generation": generation},
+					"rotation_days": 30,
+				},
+			},
+		})
+	}
+
+	tests := []struct {
+		name       string
+		generation string
+		want       bool
+	}{
+		{
+			"keepers unchanged",
+			"1",
+			false,
+		},
+		{
+			"keepers changed",
+			"2",
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff, err := resourceServiceUser().Diff(context.Background(), state, config(tt.generation), nil)
+			if err != nil {
+				t.Fatalf("Diff() error = %v", err)
+			}
+
+			for _, k := range []string{"password", "password_hash", "password_updated_at", "access_cert", "access_key", "access_cert_not_after"} {
+				got := diff != nil && diff.Attributes[k] != nil && diff.Attributes[k].NewComputed
+				if got != tt.want {
+					t.Errorf("Diff() %s computed = %v, want %v", k, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func Test_validateRedisACLCategory(t *testing.T) {
+	tests := []struct {
+		rule    string
+		wantErr bool
+	}{
+		{"+@all", false},
+		{"-@dangerous", false},
+		{"+@sortedset", false},
+		{"@read", true},
+		{"+read", true},
+		{"+@unknown", true},
+		{"+@READ", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			if _, errs := validateRedisACLCategory(tt.rule, "redis_acl_categories"); (len(errs) > 0) != tt.wantErr {
+				t.Errorf("validateRedisACLCategory() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_validateRedisACLCommand(t *testing.T) {
+	tests := []struct {
+		rule    string
+		wantErr bool
+	}{
+		{"+get", false},
+		{"-flushall", false},
+		{"+config|get", false},
+		{"+client|no-evict", false},
+		{"get", true},
+		{"+@read", true},
+		{"+config|", true},
+		{"+get set", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			if _, errs := validateRedisACLCommand(tt.rule, "redis_acl_commands"); (len(errs) > 0) != tt.wantErr {
+				t.Errorf("validateRedisACLCommand() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_validateRedisACLPattern(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		wantErr bool
+	}{
+		{"glob", "cache:*", false},
+		{"any", "*", false},
+		{"empty", "", true},
+		{"whitespace", "cache *", true},
+		{"key-prefix", "~cache:*", true},
+		{"channel-prefix", "&events:*", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, errs := validateRedisACLPattern(tt.pattern, "redis_acl_keys"); (len(errs) > 0) != tt.wantErr {
+				t.Errorf("validateRedisACLPattern() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_copyServiceUserPasswordFromAPIResponseToTerraform(t *testing.T) {
+	oldHash := serviceUserPasswordHashWithSalt("old-secret", "00")
+	newHash := serviceUserPasswordHashWithSalt("new-secret", "00")
+
+	tests := []struct {
+		name         string
+		config       map[string]interface{}
+		state        map[string]string
+		wantPassword string
+		wantHash     string
+		wantUpdated  bool
+	}{
+		{
+			"first-read",
+			map[string]interface{}{},
+			nil,
+			"new-secret",
+			"",
+			true,
+		},
+		{
+			"unchanged",
+			map[string]interface{}{},
+			map[string]string{"password": "new-secret", "password_hash": newHash},
+			"new-secret",
+			newHash,
+			false,
+		},
+		{
+			"drift",
+			map[string]interface{}{},
+			map[string]string{"password": "old-secret", "password_hash": oldHash},
+			"new-secret",
+			"",
+			true,
+		},
+		{
+			"ignored-drift",
+			map[string]interface{}{"ignore_password_drift": true},
+			map[string]string{"password": "old-secret", "password_hash": oldHash},
+			"old-secret",
+			"",
+			true,
+		},
+		{
+			"write-only",
+			map[string]interface{}{"password_write_only": true},
+			map[string]string{"password_hash": oldHash},
+			"",
+			"",
+			true,
+		},
+		{
+			"write-only-unchanged",
+			map[string]interface{}{"password_write_only": true},
+			map[string]string{"password_hash": newHash},
+			"",
+			newHash,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, aivenServiceUserSchema, tt.config)
+			for k, v := range tt.state {
+				if err := d.Set(k, v); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tt.state != nil {
+				if err := d.Set("password_updated_at", "2021-11-01T00:00:00Z"); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			user := &aiven.ServiceUser{Username: "foo", Password: "new-secret"}
+			if err := copyServiceUserPasswordFromAPIResponseToTerraform(d, user); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := d.Get("password").(string); got != tt.wantPassword {
+				t.Errorf("password = %q, want %q", got, tt.wantPassword)
+			}
+			// a new hash has a random salt, it is only checked against the password
+			got := d.Get("password_hash").(string)
+			if !serviceUserPasswordHashMatches("new-secret", got) || (tt.wantHash != "" && got != tt.wantHash) {
+				t.Errorf("password_hash = %q, want a hash of the password of the user", got)
+			}
+			if got := d.Get("password_updated_at").(string) != "2021-11-01T00:00:00Z"; got != tt.wantUpdated {
+				t.Errorf("password_updated_at changed = %v, want %v", got, tt.wantUpdated)
+			}
+		})
+	}
+}
+
+func Test_serviceUserPasswordHashMatches(t *testing.T) {
+	hash, err := serviceUserPasswordHash("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := serviceUserPasswordHash("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash == other {
+		t.Errorf("serviceUserPasswordHash() = %q twice, want a new salt for every hash", hash)
+	}
+
+	tests := []struct {
+		name     string
+		password string
+		hash     string
+		want     bool
+	}{
+		{"match", "secret", hash, true},
+		{"other-salt", "secret", other, true},
+		{"other-password", "other-secret", hash, false},
+		{"unsalted", "secret", "2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b", false},
+		{"empty-hash", "secret", "", false},
+		{"empty-password", "", hash, false},
+		{"both-empty", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := serviceUserPasswordHashMatches(tt.password, tt.hash); got != tt.want {
+				t.Errorf("serviceUserPasswordHashMatches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_serviceUserNewPassword(t *testing.T) {
+	tests := []struct {
+		name            string
+		rotate          bool
+		passwordChanged bool
+		password        string
+		currentPassword string
+		want            string
+		wantNil         bool
+		wantErr         bool
+	}{
+		{"rotation", true, true, "new-secret", "", "", true, false},
+		{"password-changed", false, true, "new-secret", "", "new-secret", false, false},
+		{"authentication-only", false, false, "new-secret", "old-secret", "old-secret", false, false},
+		{"authentication-only-write-only", false, false, "", "old-secret", "old-secret", false, false},
+		{"unknown-current-password", false, false, "", "", "", true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := serviceUserNewPassword(tt.rotate, tt.passwordChanged, tt.password, tt.currentPassword)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("serviceUserNewPassword() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (got == nil) != tt.wantNil || (got != nil && *got != tt.want) {
+				t.Errorf("serviceUserNewPassword() = %v, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,247 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// staticIP is a static IP address of a project, the static IP addresses are not covered by
+// aiven-go-client
+type staticIP struct {
+	CloudName         string `json:"cloud_name"`
+	IPAddress         string `json:"ip_address"`
+	ServiceName       string `json:"service_name"`
+	State             string `json:"state"`
+	StaticIPAddressID string `json:"static_ip_address_id"`
+}
+
+var aivenStaticIPSchema = map[string]*schema.Schema{
+	"project": commonSchemaProjectReference,
+	"cloud_name": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: complex("The cloud the static IP address is reserved in, it has to be the cloud of the services using the address.").forceNew().build(),
+	},
+	"static_ip_address_id": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Identifier of the static IP address",
+	},
+	"ip_address": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The static IP address",
+	},
+	"service_name": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Name of the service the static IP address is associated with, empty when the address is not associated",
+	},
+	"state": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "State of the static IP address. One of `creating`, `created`, `available`, `assigned`, `deleting` or `deleted`.",
+	},
+}
+
+func resourceStaticIP() *schema.Resource {
+	return &schema.Resource{
+		Description:   "The Static IP resource reserves a static IP address in a cloud for the services of a project.",
+		CreateContext: resourceStaticIPCreate,
+		ReadContext:   resourceStaticIPRead,
+		DeleteContext: resourceStaticIPDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceStaticIPState,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: aivenStaticIPSchema,
+	}
+}
+
+func resourceStaticIPCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	projectName := d.Get("project").(string)
+
+	var ip staticIP
+	path := clientRequestPath("project", projectName, "static-ips")
+	req := map[string]string{"cloud_name": d.Get("cloud_name").(string)}
+	if err := clientRequest(client, http.MethodPost, path, req, &ip); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(buildResourceID(projectName, ip.StaticIPAddressID))
+
+	w := &StaticIPWaiter{
+		Client:     client,
+		Project:    projectName,
+		StaticIPID: ip.StaticIPAddressID,
+	}
+
+	_, err := w.Conf(d.Timeout(schema.TimeoutCreate), []string{"creating"}, []string{"created", "available", "assigned"}).WaitForStateContext(ctx)
+	if err != nil {
+		return diag.Errorf("error waiting for static IP address to be created: %s", err)
+	}
+
+	return resourceStaticIPRead(ctx, d, m)
+}
+
+func resourceStaticIPRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	projectName, staticIPID := splitResourceID2(d.Id())
+	ip, err := getStaticIP(client, projectName, staticIPID)
+	if err != nil {
+		return diag.FromErr(resourceReadHandleNotFound(err, d))
+	}
+
+	if err := d.Set("project", projectName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("cloud_name", ip.CloudName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("static_ip_address_id", ip.StaticIPAddressID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("ip_address", ip.IPAddress); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", ip.ServiceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("state", ip.State); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceStaticIPDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	projectName, staticIPID := splitResourceID2(d.Id())
+	path := clientRequestPath("project", projectName, "static-ips", staticIPID)
+	if err := clientRequest(client, http.MethodDelete, path, nil, nil); err != nil {
+		if aiven.IsNotFound(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	w := &StaticIPWaiter{
+		Client:     client,
+		Project:    projectName,
+		StaticIPID: staticIPID,
+	}
+
+	_, err := w.Conf(d.Timeout(schema.TimeoutDelete), []string{"creating", "created", "available", "deleting"}, []string{"deleted"}).WaitForStateContext(ctx)
+	if err != nil {
+		return diag.Errorf("error waiting for static IP address to be deleted: %s", err)
+	}
+
+	return nil
+}
+
+func resourceStaticIPState(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if len(strings.Split(d.Id(), "/")) != 2 {
+		return nil, fmt.Errorf("invalid identifier %v, expected <project_name>/<static_ip_address_id>", d.Id())
+	}
+
+	di := resourceStaticIPRead(ctx, d, m)
+	if di.HasError() {
+		return nil, fmt.Errorf("cannot get static IP address: %v", di)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// getStaticIPs returns the static IP addresses of a project
+func getStaticIPs(client *aiven.Client, projectName string) ([]staticIP, error) {
+	var r struct {
+		StaticIPs []staticIP `json:"static_ips"`
+	}
+
+	path := clientRequestPath("project", projectName, "static-ips")
+	if err := clientRequest(client, http.MethodGet, path, nil, &r); err != nil {
+		return nil, err
+	}
+
+	return r.StaticIPs, nil
+}
+
+// getStaticIP returns a static IP address of a project, deleted addresses are not found
+func getStaticIP(client *aiven.Client, projectName, staticIPID string) (*staticIP, error) {
+	ips, err := getStaticIPs(client, projectName)
+	if err != nil {
+		return nil, err
+	}
+
+	if ip := findStaticIP(ips, staticIPID); ip != nil {
+		return ip, nil
+	}
+
+	return nil, aiven.Error{Status: 404, Message: fmt.Sprintf("static IP address %s not found", staticIPID)}
+}
+
+// findStaticIP returns a static IP address that is not deleted or nil
+func findStaticIP(ips []staticIP, staticIPID string) *staticIP {
+	for i := range ips {
+		if ips[i].StaticIPAddressID == staticIPID && ips[i].State != "deleted" {
+			return &ips[i]
+		}
+	}
+
+	return nil
+}
+
+// StaticIPWaiter is used to wait for a static IP address to change its state
+type StaticIPWaiter struct {
+	Client     *aiven.Client
+	Project    string
+	StaticIPID string
+}
+
+// RefreshFunc will call the Aiven client and refresh its state.
+func (w *StaticIPWaiter) RefreshFunc() resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		ip, err := getStaticIP(w.Client, w.Project, w.StaticIPID)
+		if err != nil {
+			if aiven.IsNotFound(err) {
+				return &staticIP{StaticIPAddressID: w.StaticIPID, State: "deleted"}, "deleted", nil
+			}
+			return nil, "", err
+		}
+
+		log.Printf("[DEBUG] static IP address %s is %s", w.StaticIPID, ip.State)
+
+		return ip, ip.State, nil
+	}
+}
+
+// Conf sets up the configuration to refresh.
+func (w *StaticIPWaiter) Conf(timeout time.Duration, pending, target []string) *resource.StateChangeConf {
+	return &resource.StateChangeConf{
+		Pending:    pending,
+		Target:     target,
+		Refresh:    w.RefreshFunc(),
+		Delay:      5 * time.Second,
+		Timeout:    timeout,
+		MinTimeout: 5 * time.Second,
+	}
+}
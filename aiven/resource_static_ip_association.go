@@ -0,0 +1,133 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var aivenStaticIPAssociationSchema = map[string]*schema.Schema{
+	"project": commonSchemaProjectReference,
+	"static_ip_address_id": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: complex("Identifier of the static IP address.").forceNew().referenced().build(),
+	},
+	"service_name": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: complex("Name of the service the static IP address is associated with. The service has to run in the cloud of the address, and it uses its associated addresses once `static_ips` is enabled in its user configuration.").forceNew().referenced().build(),
+	},
+	"ip_address": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The static IP address",
+	},
+	"state": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "State of the static IP address, `assigned` once the service uses the address.",
+	},
+}
+
+func resourceStaticIPAssociation() *schema.Resource {
+	return &schema.Resource{
+		Description:   "The Static IP Association resource associates a static IP address with a service.",
+		CreateContext: resourceStaticIPAssociationCreate,
+		ReadContext:   resourceStaticIPAssociationRead,
+		DeleteContext: resourceStaticIPAssociationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceStaticIPAssociationState,
+		},
+
+		Schema: aivenStaticIPAssociationSchema,
+	}
+}
+
+func resourceStaticIPAssociationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	projectName := d.Get("project").(string)
+	staticIPID := d.Get("static_ip_address_id").(string)
+
+	path := clientRequestPath("project", projectName, "static-ips", staticIPID, "association")
+	req := map[string]string{"service_name": d.Get("service_name").(string)}
+	if err := clientRequest(client, http.MethodPut, path, req, nil); err != nil {
+		return diag.Errorf("cannot associate static IP address %s: %s", staticIPID, err)
+	}
+
+	d.SetId(buildResourceID(projectName, staticIPID))
+
+	return resourceStaticIPAssociationRead(ctx, d, m)
+}
+
+func resourceStaticIPAssociationRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	projectName, staticIPID := splitResourceID2(d.Id())
+	ip, err := getStaticIP(client, projectName, staticIPID)
+	if err != nil {
+		return diag.FromErr(resourceReadHandleNotFound(err, d))
+	}
+
+	if ip.ServiceName == "" {
+		log.Printf("[DEBUG] static IP address %s is no longer associated with a service", staticIPID)
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("project", projectName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("static_ip_address_id", staticIPID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", ip.ServiceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("ip_address", ip.IPAddress); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("state", ip.State); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceStaticIPAssociationDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	projectName, staticIPID := splitResourceID2(d.Id())
+	path := clientRequestPath("project", projectName, "static-ips", staticIPID, "association")
+	if err := clientRequest(client, http.MethodDelete, path, nil, nil); err != nil && !aiven.IsNotFound(err) {
+		return diag.Errorf("cannot dissociate static IP address %s: %s", staticIPID, err)
+	}
+
+	return nil
+}
+
+func resourceStaticIPAssociationState(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if len(strings.Split(d.Id(), "/")) != 2 {
+		return nil, fmt.Errorf("invalid identifier %v, expected <project_name>/<static_ip_address_id>", d.Id())
+	}
+
+	di := resourceStaticIPAssociationRead(ctx, d, m)
+	if di.HasError() {
+		return nil, fmt.Errorf("cannot get static IP address association: %v", di)
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("static IP address is not associated with a service")
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccAivenStaticIP_basic(t *testing.T) {
+	resourceName := "aiven_static_ip.foo"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckAivenServiceResourceDestroy,
+			testAccCheckAivenStaticIPResourceDestroy,
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStaticIPResource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "project", os.Getenv("AIVEN_PROJECT_NAME")),
+					resource.TestCheckResourceAttr(resourceName, "cloud_name", "google-europe-west1"),
+					resource.TestCheckResourceAttrSet(resourceName, "static_ip_address_id"),
+					resource.TestCheckResourceAttrSet(resourceName, "ip_address"),
+					resource.TestCheckResourceAttr("aiven_static_ip_association.foo", "service_name", fmt.Sprintf("test-acc-sr-%s", rName)),
+					resource.TestCheckResourceAttrPair("aiven_static_ip_association.foo", "ip_address", resourceName, "ip_address"),
+					resource.TestCheckResourceAttr("data.aiven_service_ip_addresses.foo", "static_ip_addresses.#", "1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccStaticIPResource(name string) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_pg" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-4"
+			service_name = "test-acc-sr-%s"
+		}
+
+		resource "aiven_static_ip" "foo" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+		}
+
+		resource "aiven_static_ip_association" "foo" {
+			project = aiven_static_ip.foo.project
+			static_ip_address_id = aiven_static_ip.foo.static_ip_address_id
+			service_name = aiven_pg.bar.service_name
+		}
+
+		data "aiven_service_ip_addresses" "foo" {
+			project = aiven_static_ip_association.foo.project
+			service_name = aiven_static_ip_association.foo.service_name
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name)
+}
+
+func testAccCheckAivenStaticIPResourceDestroy(s *terraform.State) error {
+	c := testAccProvider.Meta().(*providerMeta).client
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aiven_static_ip" {
+			continue
+		}
+
+		projectName, staticIPID := splitResourceID2(rs.Primary.ID)
+		ip, err := getStaticIP(c, projectName, staticIPID)
+		if err != nil && !aiven.IsNotFound(err) {
+			return err
+		}
+
+		if ip != nil {
+			return fmt.Errorf("static IP address (%s) still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func Test_findStaticIP(t *testing.T) {
+	ips := []staticIP{
+		{CloudName: "google-europe-west1", IPAddress: "10.0.0.1", ServiceName: "pg", State: "assigned", StaticIPAddressID: "ip1"},
+		{CloudName: "google-europe-west1", IPAddress: "10.0.0.2", State: "deleted", StaticIPAddressID: "ip2"},
+	}
+
+	tests := []struct {
+		name       string
+		staticIPID string
+		want       string
+	}{
+		{"assigned", "ip1", "10.0.0.1"},
+		{"deleted", "ip2", ""},
+		{"unknown", "ip3", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := findStaticIP(ips, tt.staticIPID)
+			if (got == nil) != (tt.want == "") || (got != nil && got.IPAddress != tt.want) {
+				t.Errorf("findStaticIP() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// userToken is an API token of the user of the provider credentials, the tokens are not covered
+// by aiven-go-client
+type userToken struct {
+	Description     string   `json:"description,omitempty"`
+	MaxAgeSeconds   *int     `json:"max_age_seconds,omitempty"`
+	ExtendWhenUsed  bool     `json:"extend_when_used"`
+	Scopes          []string `json:"scopes,omitempty"`
+	FullToken       string   `json:"full_token,omitempty"`
+	TokenPrefix     string   `json:"token_prefix,omitempty"`
+	CreateTime      string   `json:"create_time,omitempty"`
+	ExpiryTime      *string  `json:"expiry_time,omitempty"`
+	LastUsedTime    *string  `json:"last_used_time,omitempty"`
+	CurrentlyActive bool     `json:"currently_active,omitempty"`
+}
+
+var aivenUserTokenSchema = map[string]*schema.Schema{
+	"description": {
+		Type:         schema.TypeString,
+		Optional:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringLenBetween(0, 1000),
+		Description:  complex("Description of the token, for example the system using it.").forceNew().maxLen(1000).build(),
+	},
+	"max_age_seconds": {
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.IntAtLeast(600),
+		Description:  complex("Time the token remains valid since its creation, or since it was last used when `extend_when_used` is set. The token does not expire when not set.").forceNew().build(),
+	},
+	"extend_when_used": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		ForceNew:    true,
+		Default:     false,
+		Description: complex("Extend the validity of the token by `max_age_seconds` every time it is used.").forceNew().defaultValue(false).build(),
+	},
+	"scopes": {
+		Type:        schema.TypeSet,
+		Optional:    true,
+		ForceNew:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: complex("Scopes the token is restricted to, for example `projects:read` or `services`. The token has the full access of the user when not set.").forceNew().build(),
+	},
+	"token": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Sensitive:   true,
+		Description: "The API token, only known to the resource that created it",
+	},
+	"token_prefix": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Prefix of the token identifying it without revealing it",
+	},
+	"create_time": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Time of creation",
+	},
+	"expiry_time": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Time the token expires at unless it is used and extended before, empty for tokens that do not expire",
+	},
+	"last_used_time": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Time the token was last used",
+	},
+	"currently_active": {
+		Type:        schema.TypeBool,
+		Computed:    true,
+		Description: "Whether the token is the one the provider uses",
+	},
+}
+
+func resourceUserToken() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+The User Token resource creates an API token of the user of the provider credentials, for example
+short-lived and scoped credentials of a CI system or an application.
+
+The token is only available in the state of the resource that created it. When the token expires or
+is revoked outside of Terraform, the resource is planned to be created again.
+`,
+		CreateContext: resourceUserTokenCreate,
+		ReadContext:   resourceUserTokenRead,
+		DeleteContext: resourceUserTokenDelete,
+
+		Schema: aivenUserTokenSchema,
+	}
+}
+
+func resourceUserTokenCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	req := userToken{
+		Description:    d.Get("description").(string),
+		ExtendWhenUsed: d.Get("extend_when_used").(bool),
+		Scopes:         flattenToString(d.Get("scopes").(*schema.Set).List()),
+	}
+	if v, ok := d.GetOk("max_age_seconds"); ok {
+		maxAge := v.(int)
+		req.MaxAgeSeconds = &maxAge
+	}
+
+	var t userToken
+	if err := clientRequest(client, http.MethodPost, clientRequestPath("access_token"), req, &t); err != nil {
+		return diag.Errorf("cannot create user token: %s", err)
+	}
+
+	d.SetId(t.TokenPrefix)
+	if err := d.Set("token", t.FullToken); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceUserTokenRead(ctx, d, m)
+}
+
+func resourceUserTokenRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	t, err := getUserToken(client, d.Id())
+	if err != nil {
+		return diag.FromErr(resourceReadHandleNotFound(err, d))
+	}
+
+	if err := d.Set("description", t.Description); err != nil {
+		return diag.FromErr(err)
+	}
+	if t.MaxAgeSeconds != nil {
+		if err := d.Set("max_age_seconds", *t.MaxAgeSeconds); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := d.Set("extend_when_used", t.ExtendWhenUsed); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("scopes", t.Scopes); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("token_prefix", t.TokenPrefix); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("create_time", t.CreateTime); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("expiry_time", stringValue(t.ExpiryTime)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("last_used_time", stringValue(t.LastUsedTime)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("currently_active", t.CurrentlyActive); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceUserTokenDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	if d.Get("currently_active").(bool) {
+		return diag.Errorf("cannot revoke user token %s, the provider uses it", d.Id())
+	}
+
+	err := clientRequest(client, http.MethodDelete, clientRequestPath("access_token", d.Id()), nil, nil)
+	if err != nil && !aiven.IsNotFound(err) {
+		return diag.Errorf("cannot revoke user token %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// getUserToken returns a token of the user by its prefix, expired and revoked tokens are not found
+func getUserToken(client *aiven.Client, tokenPrefix string) (*userToken, error) {
+	var r struct {
+		Tokens []userToken `json:"tokens"`
+	}
+
+	if err := clientRequest(client, http.MethodGet, clientRequestPath("access_token"), nil, &r); err != nil {
+		return nil, err
+	}
+
+	if tk := findUserToken(r.Tokens, tokenPrefix); tk != nil {
+		return tk, nil
+	}
+
+	return nil, aiven.Error{Status: 404, Message: fmt.Sprintf("user token %s not found", tokenPrefix)}
+}
+
+// findUserToken returns the token with the given prefix or nil
+func findUserToken(tokens []userToken, tokenPrefix string) *userToken {
+	for i := range tokens {
+		if tokens[i].TokenPrefix == tokenPrefix {
+			return &tokens[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccAivenUserToken_basic(t *testing.T) {
+	resourceName := "aiven_user_token.foo"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenUserTokenResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserTokenResource(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", fmt.Sprintf("test-acc-token-%s", rName)),
+					resource.TestCheckResourceAttr(resourceName, "max_age_seconds", "3600"),
+					resource.TestCheckResourceAttr(resourceName, "scopes.#", "1"),
+					resource.TestCheckResourceAttrSet(resourceName, "token"),
+					resource.TestCheckResourceAttrSet(resourceName, "token_prefix"),
+					resource.TestCheckResourceAttrSet(resourceName, "expiry_time"),
+				),
+			},
+		},
+	})
+}
+
+func testAccUserTokenResource(name string) string {
+	return fmt.Sprintf(`
+		resource "aiven_user_token" "foo" {
+			description = "test-acc-token-%s"
+			max_age_seconds = 3600
+			extend_when_used = true
+			scopes = ["projects:read"]
+		}
+		`, name)
+}
+
+func testAccCheckAivenUserTokenResourceDestroy(s *terraform.State) error {
+	c := testAccProvider.Meta().(*providerMeta).client
+
+	// loop through the resources in state, verifying each user token is revoked
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aiven_user_token" {
+			continue
+		}
+
+		_, err := getUserToken(c, rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("user token (%s) still exists", rs.Primary.ID)
+		}
+		if !aiven.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func Test_findUserToken(t *testing.T) {
+	tokens := []userToken{
+		{TokenPrefix: "abc", Description: "ci"},
+		{TokenPrefix: "def", Description: "terraform"},
+	}
+
+	tests := []struct {
+		name        string
+		tokenPrefix string
+		want        string
+	}{
+		{"first", "abc", "ci"},
+		{"second", "def", "terraform"},
+		{"unknown", "ghi", ""},
+		{"empty-prefix", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := findUserToken(tokens, tt.tokenPrefix)
+			if (got == nil) != (tt.want == "") || (got != nil && got.Description != tt.want) {
+				t.Errorf("findUserToken() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -104,7 +104,7 @@ func resourceVPCPeeringConnectionCreate(ctx context.Context, d *schema.ResourceD
 		cidrs  []string
 	)
 
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 	projectName, vpcID := splitResourceID2(d.Get("vpc_id").(string))
 	if projectName == "" || vpcID == "" {
 		return diag.Errorf("incorrect VPC ID, expected structure <PROJECT_NAME>/<VPC_ID>")
@@ -272,7 +272,7 @@ func parsePeeringVPCId(resourceID string) (string, string, string, string, *stri
 
 func resourceVPCPeeringConnectionRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var pc *aiven.VPCPeeringConnection
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, vpcID, peerCloudAccount, peerVPC, peerRegion := parsePeeringVPCId(d.Id())
 	isAzure, err := isAzureVPCPeeringConnection(d, client)
@@ -306,7 +306,7 @@ func resourceVPCPeeringConnectionRead(_ context.Context, d *schema.ResourceData,
 }
 
 func resourceVPCPeeringConnectionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, vpcID, peerCloudAccount, peerVPC, peerRegion := parsePeeringVPCId(d.Id())
 
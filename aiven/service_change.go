@@ -19,6 +19,7 @@ type ServiceChangeWaiter struct {
 	Operation   string
 	Project     string
 	ServiceName string
+	PoweredOff  bool
 }
 
 const (
@@ -26,6 +27,7 @@ const (
 	aivenPendingState          = "REBUILDING"
 	aivenRebalancingState      = "REBALANCING"
 	aivenServicesStartingState = "WAITING_FOR_SERVICES"
+	aivenPoweroffState         = "POWEROFF"
 )
 
 // RefreshFunc will call the Aiven client and refresh its state.
@@ -40,25 +42,72 @@ func (w *ServiceChangeWaiter) RefreshFunc() resource.StateRefreshFunc {
 			return nil, "", err
 		}
 
-		state := service.State
-		if w.Operation == "update" {
-			// When updating service don't wait for it to enter RUNNING state because that can take
-			// very long time if for example service plan or cloud it runs in is changed and the
-			// service has a lot of data. If the service was already previously in RUNNING state we
-			// can manage the associated resources even if the service is rebuilding.
-			state = aivenTargetState
-		}
+		return service, w.state(service), nil
+	}
+}
 
-		if state == aivenTargetState && !backupsReady(service) {
-			state = aivenServicesStartingState
+// state maps the state of a service to the state the waiter is waiting for
+func (w *ServiceChangeWaiter) state(service *aiven.Service) string {
+	state := service.State
+	if w.PoweredOff {
+		// a powered off service has no nodes, backups or endpoints to wait for
+		if state != aivenPoweroffState {
+			state = aivenPendingState
 		}
 
-		if state == aivenTargetState && !grafanaReady(service) {
-			state = aivenServicesStartingState
+		return state
+	}
+
+	if w.Operation == "power_on" && state == aivenPoweroffState {
+		// the service can still be reported as powered off right after it is powered on
+		state = aivenPendingState
+	}
+
+	if w.Operation == "update" {
+		// When updating service don't wait for it to enter RUNNING state because that can take
+		// very long time if for example service plan or cloud it runs in is changed and the
+		// service has a lot of data. If the service was already previously in RUNNING state we
+		// can manage the associated resources even if the service is rebuilding.
+		state = aivenTargetState
+	}
+
+	if state != aivenTargetState {
+		logServiceNodeStates(service)
+	}
+
+	if state == aivenTargetState && !backupsReady(service) {
+		state = aivenServicesStartingState
+	}
+
+	if state == aivenTargetState && !grafanaReady(service) {
+		state = aivenServicesStartingState
+	}
+
+	return state
+}
+
+// logServiceNodeStates logs the progress of a service that is being built or migrated
+func logServiceNodeStates(service *aiven.Service) {
+	if len(service.NodeStates) == 0 {
+		return
+	}
+
+	running := 0
+	for _, n := range service.NodeStates {
+		if n.State == "running" {
+			running++
 		}
 
-		return service, state, nil
+		for _, p := range n.ProgressUpdates {
+			if p.Completed {
+				continue
+			}
+			log.Printf("[INFO] service %s node %s (%s) %s: %d/%d %s",
+				service.Name, n.Name, n.Role, p.Phase, p.Current, p.Max, p.Unit)
+		}
 	}
+
+	log.Printf("[INFO] service %s is %s, %d of %d nodes running", service.Name, service.State, running, len(service.NodeStates))
 }
 
 func grafanaReady(service *aiven.Service) bool {
@@ -130,9 +179,14 @@ func backupsReady(service *aiven.Service) bool {
 func (w *ServiceChangeWaiter) Conf(timeout time.Duration) *resource.StateChangeConf {
 	log.Printf("[DEBUG] Service waiter timeout %.0f minutes", timeout.Minutes())
 
+	target := aivenTargetState
+	if w.PoweredOff {
+		target = aivenPoweroffState
+	}
+
 	return &resource.StateChangeConf{
 		Pending:                   []string{aivenPendingState, aivenRebalancingState, aivenServicesStartingState},
-		Target:                    []string{aivenTargetState},
+		Target:                    []string{target},
 		Refresh:                   w.RefreshFunc(),
 		Delay:                     10 * time.Second,
 		Timeout:                   timeout,
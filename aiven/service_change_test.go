@@ -0,0 +1,106 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+)
+
+func TestServiceChangeWaiter_state(t *testing.T) {
+	backups := []*aiven.Backup{{BackupTime: "2021-11-20T12:00:00Z", DataSize: 1024}}
+
+	tests := []struct {
+		name       string
+		operation  string
+		poweredOff bool
+		service    *aiven.Service
+		want       string
+	}{
+		{
+			"create rebuilding",
+			"create",
+			false,
+			&aiven.Service{Type: "pg", State: "REBUILDING"},
+			aivenPendingState,
+		},
+		{
+			"create running without backups",
+			"create",
+			false,
+			&aiven.Service{Type: "pg", State: "RUNNING"},
+			aivenServicesStartingState,
+		},
+		{
+			"create running with backups",
+			"create",
+			false,
+			&aiven.Service{Type: "pg", State: "RUNNING", Backups: backups},
+			aivenTargetState,
+		},
+		{
+			"update rebuilding",
+			"update",
+			false,
+			&aiven.Service{Type: "kafka", State: "REBUILDING"},
+			aivenTargetState,
+		},
+		{
+			"migration rebuilding",
+			"migration",
+			false,
+			&aiven.Service{Type: "kafka", State: "REBUILDING"},
+			aivenPendingState,
+		},
+		{
+			"migration rebalancing",
+			"migration",
+			false,
+			&aiven.Service{Type: "kafka", State: "REBALANCING"},
+			aivenRebalancingState,
+		},
+		{
+			"migration running",
+			"migration",
+			false,
+			&aiven.Service{Type: "kafka", State: "RUNNING"},
+			aivenTargetState,
+		},
+		{
+			"power off running",
+			"power_off",
+			true,
+			&aiven.Service{Type: "pg", State: "RUNNING", Backups: backups},
+			aivenPendingState,
+		},
+		{
+			"power off done",
+			"power_off",
+			true,
+			&aiven.Service{Type: "pg", State: "POWEROFF"},
+			aivenPoweroffState,
+		},
+		{
+			"power on still powered off",
+			"power_on",
+			false,
+			&aiven.Service{Type: "pg", State: "POWEROFF"},
+			aivenPendingState,
+		},
+		{
+			"power on running",
+			"power_on",
+			false,
+			&aiven.Service{Type: "pg", State: "RUNNING", Backups: backups},
+			aivenTargetState,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &ServiceChangeWaiter{Operation: tt.operation, PoweredOff: tt.poweredOff}
+			if got := w.state(tt.service); got != tt.want {
+				t.Errorf("state() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
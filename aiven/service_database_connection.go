@@ -0,0 +1,284 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/go-sql-driver/mysql"
+	"github.com/gocql/gocql"
+	"github.com/lib/pq"
+)
+
+// serviceDatabaseConnectTimeout is the timeout of connecting to the database of a service
+const serviceDatabaseConnectTimeout = 30 * time.Second
+
+// serviceConnection holds the admin connection details of a PostgreSQL, MySQL or Cassandra
+// service together with the CA certificate of its project
+type serviceConnection struct {
+	ServiceType string
+	Host        string
+	Port        int
+	User        string
+	Password    string
+	Database    string
+	CA          string
+}
+
+// getServiceConnection returns the admin connection details of a service, the service has to
+// be reachable from where Terraform runs
+func getServiceConnection(client *aiven.Client, projectName, serviceName string) (*serviceConnection, error) {
+	service, err := client.Services.Get(projectName, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := serviceConnectionFromService(service)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get connection details of service %s/%s: %s", projectName, serviceName, err)
+	}
+
+	if conn.CA, err = client.CA.Get(projectName); err != nil {
+		return nil, fmt.Errorf("cannot get CA certificate of project %s: %s", projectName, err)
+	}
+
+	return conn, nil
+}
+
+// serviceConnectionFromService reads the admin connection details from the service URI
+// parameters, falling back to the service URI
+func serviceConnectionFromService(service *aiven.Service) (*serviceConnection, error) {
+	conn := &serviceConnection{
+		ServiceType: service.Type,
+		Host:        service.URIParams["host"],
+		User:        service.URIParams["user"],
+		Password:    service.URIParams["password"],
+		Database:    service.URIParams["dbname"],
+	}
+	port := service.URIParams["port"]
+
+	if conn.Host == "" && service.URI != "" {
+		u, err := url.Parse(service.URI)
+		if err != nil {
+			return nil, fmt.Errorf("invalid service URI: %s", err)
+		}
+		conn.Host, port = u.Hostname(), u.Port()
+		conn.User = u.User.Username()
+		conn.Password, _ = u.User.Password()
+		conn.Database = strings.TrimPrefix(u.Path, "/")
+	}
+
+	if conn.Host == "" || port == "" || conn.User == "" {
+		return nil, fmt.Errorf("the service does not have admin connection details")
+	}
+
+	var err error
+	if conn.Port, err = strconv.Atoi(port); err != nil {
+		return nil, fmt.Errorf("invalid port %q: %s", port, err)
+	}
+
+	return conn, nil
+}
+
+// certPool returns a pool with the CA certificate of the project of the service
+func (c *serviceConnection) certPool() (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM([]byte(c.CA)) {
+		return nil, fmt.Errorf("cannot parse CA certificate")
+	}
+
+	return pool, nil
+}
+
+// postgresConnectionString returns a lib/pq connection string to a database of a PostgreSQL
+// service, the database of the service URI is used when database is empty
+func postgresConnectionString(c *serviceConnection, database, caFile string) string {
+	if database == "" {
+		database = c.Database
+	}
+
+	quote := func(s string) string {
+		return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+	}
+
+	return strings.Join([]string{
+		"host=" + quote(c.Host),
+		"port=" + strconv.Itoa(c.Port),
+		"user=" + quote(c.User),
+		"password=" + quote(c.Password),
+		"dbname=" + quote(database),
+		"sslmode=verify-full",
+		"sslrootcert=" + quote(caFile),
+		"connect_timeout=" + strconv.Itoa(int(serviceDatabaseConnectTimeout.Seconds())),
+	}, " ")
+}
+
+// withServicePostgres runs f with a connection to a database of a PostgreSQL service
+func withServicePostgres(c *serviceConnection, database string, f func(*sql.DB) error) error {
+	// lib/pq reads the CA certificate from a file
+	caFile, err := ioutil.TempFile("", "aiven-ca-*.pem")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(caFile.Name())
+
+	if _, err := caFile.WriteString(c.CA); err != nil {
+		caFile.Close()
+		return err
+	}
+	if err := caFile.Close(); err != nil {
+		return err
+	}
+
+	connector, err := pq.NewConnector(postgresConnectionString(c, database, caFile.Name()))
+	if err != nil {
+		return err
+	}
+
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	return f(db)
+}
+
+// serviceMySQLTLSConfigSeq makes the names of the registered MySQL TLS configurations unique
+var serviceMySQLTLSConfigSeq int64
+
+// mysqlConfig returns the go-sql-driver configuration of a MySQL service, the TLS
+// configuration has to be registered under the returned name
+func mysqlConfig(c *serviceConnection, tlsConfigName string) *mysql.Config {
+	cfg := mysql.NewConfig()
+	cfg.Net = "tcp"
+	cfg.Addr = net.JoinHostPort(c.Host, strconv.Itoa(c.Port))
+	cfg.User = c.User
+	cfg.Passwd = c.Password
+	cfg.DBName = c.Database
+	cfg.TLSConfig = tlsConfigName
+	cfg.Timeout = serviceDatabaseConnectTimeout
+
+	return cfg
+}
+
+// withServiceMySQL runs f with a connection to a MySQL service
+func withServiceMySQL(c *serviceConnection, f func(*sql.DB) error) error {
+	pool, err := c.certPool()
+	if err != nil {
+		return err
+	}
+
+	tlsConfigName := fmt.Sprintf("aiven-%d", atomic.AddInt64(&serviceMySQLTLSConfigSeq, 1))
+	if err := mysql.RegisterTLSConfig(tlsConfigName, &tls.Config{RootCAs: pool, ServerName: c.Host}); err != nil {
+		return err
+	}
+	// the connector keeps its own copy of the TLS configuration
+	defer mysql.DeregisterTLSConfig(tlsConfigName)
+
+	connector, err := mysql.NewConnector(mysqlConfig(c, tlsConfigName))
+	if err != nil {
+		return err
+	}
+
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	return f(db)
+}
+
+// withServiceCassandra runs f with a session of a Cassandra service
+func withServiceCassandra(c *serviceConnection, f func(*gocql.Session) error) error {
+	pool, err := c.certPool()
+	if err != nil {
+		return err
+	}
+
+	cluster := gocql.NewCluster(c.Host)
+	cluster.Port = c.Port
+	cluster.Authenticator = gocql.PasswordAuthenticator{Username: c.User, Password: c.Password}
+	cluster.SslOpts = &gocql.SslOptions{
+		Config:                 &tls.Config{RootCAs: pool, ServerName: c.Host},
+		EnableHostVerification: true,
+	}
+	cluster.ConnectTimeout = serviceDatabaseConnectTimeout
+	cluster.Timeout = serviceDatabaseConnectTimeout
+	// the nodes are only reachable with the public host name of the service
+	cluster.DisableInitialHostLookup = true
+
+	session, err := cluster.CreateSession()
+	if err != nil {
+		return err
+	}
+	defer session.Close()
+
+	return f(session)
+}
+
+// execServiceStatements runs statements on a PostgreSQL, MySQL or Cassandra service in order,
+// PostgreSQL statements are run in the database they are given for
+func execServiceStatements(ctx context.Context, c *serviceConnection, statements []serviceStatement) error {
+	if len(statements) == 0 {
+		return nil
+	}
+
+	execSQL := func(db *sql.DB, statements []serviceStatement) error {
+		for _, s := range statements {
+			if _, err := db.ExecContext(ctx, s.Query); err != nil {
+				return fmt.Errorf("cannot execute %q: %s", s.Query, err)
+			}
+		}
+		return nil
+	}
+
+	switch c.ServiceType {
+	case "pg":
+		// consecutive statements of the same database share a connection
+		for len(statements) > 0 {
+			n := 1
+			for n < len(statements) && statements[n].Database == statements[0].Database {
+				n++
+			}
+			batch := statements[:n]
+			err := withServicePostgres(c, batch[0].Database, func(db *sql.DB) error {
+				return execSQL(db, batch)
+			})
+			if err != nil {
+				return err
+			}
+			statements = statements[n:]
+		}
+		return nil
+	case "mysql":
+		return withServiceMySQL(c, func(db *sql.DB) error {
+			return execSQL(db, statements)
+		})
+	case "cassandra":
+		return withServiceCassandra(c, func(session *gocql.Session) error {
+			for _, s := range statements {
+				if err := session.Query(s.Query).WithContext(ctx).Exec(); err != nil {
+					return fmt.Errorf("cannot execute %q: %s", s.Query, err)
+				}
+			}
+			return nil
+		})
+	}
+
+	return fmt.Errorf("service type %q does not support database statements", c.ServiceType)
+}
+
+// serviceStatement is a statement run on a service, Database selects the database of
+// PostgreSQL statements and is empty for the database of the service URI
+type serviceStatement struct {
+	Database string
+	Query    string
+}
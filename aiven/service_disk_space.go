@@ -0,0 +1,268 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func serviceAdditionalDiskSpaceSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validation.IntAtLeast(0),
+		Description:  "Disk space in MiB added to the disk space included in the plan of the service. It has to be a multiple of the disk space step of the plan and the total disk space cannot exceed `disk_space_cap`. The disk space cannot be reduced below `disk_space_used`. When the value is removed from the configuration the additional disk space of the service is kept.",
+	}
+}
+
+func serviceDiskSpaceUsedSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "Disk space in MiB used by the service on its most utilized node. It is only refreshed for services with additional disk space, the disk space included in the plan cannot be reduced.",
+	}
+}
+
+func serviceDiskSpaceCapSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "Maximum total disk space in MiB of the plan of the service.",
+	}
+}
+
+// serviceDiskSpace is the disk space of a service in MiB, Used is nil when the usage of the
+// service is not known
+type serviceDiskSpace struct {
+	Total      int
+	Additional int
+	Used       *int
+	Cap        int
+}
+
+// getServiceDiskSpace returns the disk space of a service, the disk space included in the
+// plan and its cap come from the plan of the service type
+func getServiceDiskSpace(client *aiven.Client, projectName string, service *aiven.Service, details *serviceDetails, plan *servicePlan) *serviceDiskSpace {
+	s := &serviceDiskSpace{Total: details.DiskSpaceMB, Cap: plan.DiskSpaceCapMB}
+	if s.Total > plan.DiskSpaceMB {
+		s.Additional = s.Total - plan.DiskSpaceMB
+	}
+
+	// the usage only limits the removal of additional disk space, the metrics are not read on
+	// every refresh of the services without it
+	if s.Additional > 0 {
+		s.Used = serviceDiskSpaceUsed(client, projectName, service, s.Total)
+	}
+
+	return s
+}
+
+// serviceDiskUsageAvailable tells whether the disk usage metrics of a service with the given
+// total disk space can be read
+func serviceDiskUsageAvailable(service *aiven.Service, total int) bool {
+	return service.State == aivenTargetState && total > 0
+}
+
+// serviceDiskSpaceUsed returns the disk space used by a running service with the given total
+// disk space or nil when it is not known
+func serviceDiskSpaceUsed(client *aiven.Client, projectName string, service *aiven.Service, total int) *int {
+	if !serviceDiskUsageAvailable(service, total) {
+		return nil
+	}
+
+	usage, err := serviceDiskUsage(client, projectName, service.Name)
+	if err != nil {
+		// usage metrics are not available for all services, the disk space is still managed
+		log.Printf("[WARN] cannot get disk usage of service %s: %s", service.Name, err)
+		return nil
+	}
+
+	used := int(usage * float64(total) / 100)
+	return &used
+}
+
+// serviceDiskUsage returns the latest disk usage percentage of the most utilized node of a service
+func serviceDiskUsage(client *aiven.Client, projectName, serviceName string) (float64, error) {
+	var r struct {
+		Metrics map[string]struct {
+			Data struct {
+				Rows [][]interface{} `json:"rows"`
+			} `json:"data"`
+		} `json:"metrics"`
+	}
+
+	path := clientRequestPath("project", projectName, "service", serviceName, "metrics")
+	if err := clientRequest(client, http.MethodPost, path, map[string]string{"period": "hour"}, &r); err != nil {
+		return 0, err
+	}
+
+	usage, ok := latestServiceMetricMax(r.Metrics["disk_usage"].Data.Rows)
+	if !ok {
+		return 0, fmt.Errorf("no disk usage metrics")
+	}
+
+	return usage, nil
+}
+
+// latestServiceMetricMax returns the largest node value of the latest row of a service metric,
+// the first column of a row is its time and the following columns are the values of the nodes,
+// false is returned when no row has a value
+func latestServiceMetricMax(rows [][]interface{}) (float64, bool) {
+	var max float64
+	for i := len(rows) - 1; i >= 0; i-- {
+		if len(rows[i]) < 2 {
+			continue
+		}
+
+		found := false
+		for _, v := range rows[i][1:] {
+			if f, ok := v.(float64); ok {
+				found = true
+				if f > max {
+					max = f
+				}
+			}
+		}
+
+		if found {
+			return max, true
+		}
+	}
+
+	return 0, false
+}
+
+// setServiceDiskSpace sets the total disk space of a service, the request is not covered by
+// aiven-go-client
+func setServiceDiskSpace(client *aiven.Client, projectName, serviceName string, diskSpaceMB int) error {
+	path := clientRequestPath("project", projectName, "service", serviceName)
+	req := map[string]int{"disk_space_mb": diskSpaceMB}
+	if err := clientRequest(client, http.MethodPut, path, req, nil); err != nil {
+		return fmt.Errorf("cannot set disk space of service %s: %s", serviceName, err)
+	}
+
+	return nil
+}
+
+// resourceServiceUpdateDiskSpace applies the configured additional disk space on top of the
+// disk space of the plan of the service, the operation is the one of the service waiter
+func resourceServiceUpdateDiskSpace(ctx context.Context, d *schema.ResourceData, m interface{}, operation string) (*aiven.Service, error) {
+	client := m.(*providerMeta).client
+
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	plan, err := getServicePlan(m, projectName, d.Get("service_type").(string), d.Get("plan").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	details, err := getServiceDetails(client, projectName, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	total := plan.DiskSpaceMB + d.Get("additional_disk_space").(int)
+	if details.DiskSpaceMB == total {
+		return nil, nil
+	}
+
+	log.Printf("[INFO] setting disk space of service %s to %d MiB", serviceName, total)
+	if err := setServiceDiskSpace(client, projectName, serviceName, total); err != nil {
+		return nil, err
+	}
+
+	return resourceServiceWait(ctx, d, m, operation)
+}
+
+// resourceServiceDiskSpaceCustomizeDiff rejects disk space changes the service cannot do
+// already during plan
+func resourceServiceDiskSpaceCustomizeDiff(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !(d.HasChange("additional_disk_space") || d.HasChange("plan")) || !d.NewValueKnown("additional_disk_space") {
+		return nil
+	}
+
+	// the service type of the service specific resources is only known once they are created
+	serviceType := d.Get("service_type").(string)
+	if serviceType == "" || !d.NewValueKnown("plan") {
+		return nil
+	}
+
+	client := m.(*providerMeta).client
+	projectName := d.Get("project").(string)
+	plan, err := getServicePlan(m, projectName, serviceType, d.Get("plan").(string))
+	if err != nil {
+		log.Printf("[DEBUG] cannot validate disk space during plan: %s", err)
+		return nil
+	}
+
+	// the usage is read from the service as the one in the state can be outdated, it is only
+	// needed when the disk space shrinks and a service that does not exist yet uses no disk space
+	var used *int
+	if d.Id() != "" && resourceServiceDiskSpaceShrinks(d, m, serviceType, plan) {
+		used = resourceServiceDiskSpaceUsed(client, projectName, d.Get("service_name").(string))
+	}
+
+	return validateServiceDiskSpace(plan, d.Get("additional_disk_space").(int), used)
+}
+
+// resourceServiceDiskSpaceShrinks tells whether the planned total disk space of a service is
+// less than its current one, the disk space is assumed to shrink when the current plan is not
+// known
+func resourceServiceDiskSpaceShrinks(d *schema.ResourceDiff, m interface{}, serviceType string, plan *servicePlan) bool {
+	oldPlanName, _ := d.GetChange("plan")
+	oldAdditional, newAdditional := d.GetChange("additional_disk_space")
+
+	oldPlan, err := getServicePlan(m, d.Get("project").(string), serviceType, oldPlanName.(string))
+	if err != nil {
+		return true
+	}
+
+	return plan.DiskSpaceMB+newAdditional.(int) < oldPlan.DiskSpaceMB+oldAdditional.(int)
+}
+
+// resourceServiceDiskSpaceUsed returns the disk space used by a service or nil when it is
+// not known
+func resourceServiceDiskSpaceUsed(client *aiven.Client, projectName, serviceName string) *int {
+	service, details, err := getService(client, projectName, serviceName)
+	if err != nil {
+		log.Printf("[DEBUG] cannot get disk space used by service %s: %s", serviceName, err)
+		return nil
+	}
+
+	return serviceDiskSpaceUsed(client, projectName, service, details.DiskSpaceMB)
+}
+
+// validateServiceDiskSpace checks the total disk space of a service against the limits of its
+// plan and the disk space used by the service, the used disk space is not checked when it is
+// not known
+func validateServiceDiskSpace(plan *servicePlan, additional int, used *int) error {
+	total := plan.DiskSpaceMB + additional
+
+	if plan.DiskSpaceStepMB > 0 && additional%plan.DiskSpaceStepMB != 0 {
+		return fmt.Errorf("additional_disk_space %d MiB is not a multiple of the disk space step %d MiB of plan %s",
+			additional, plan.DiskSpaceStepMB, plan.ServicePlan)
+	}
+
+	if plan.DiskSpaceCapMB > 0 && total > plan.DiskSpaceCapMB {
+		return fmt.Errorf("total disk space %d MiB exceeds the maximum disk space %d MiB of plan %s",
+			total, plan.DiskSpaceCapMB, plan.ServicePlan)
+	}
+
+	if used == nil {
+		log.Printf("[DEBUG] disk space used by the service is not known, not checking total disk space %d MiB against it", total)
+		return nil
+	}
+
+	if total < *used {
+		return fmt.Errorf("total disk space %d MiB is less than the %d MiB used by the service", total, *used)
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func Test_validateServiceDiskSpace(t *testing.T) {
+	plan := &servicePlan{
+		ServicePlan:     "business-4",
+		DiskSpaceMB:     81920,
+		DiskSpaceCapMB:  245760,
+		DiskSpaceStepMB: 10240,
+	}
+
+	used := func(v int) *int { return &v }
+
+	tests := []struct {
+		name       string
+		additional int
+		used       *int
+		wantErr    bool
+	}{
+		{"no-additional", 0, used(0), false},
+		{"grow", 20480, used(50000), false},
+		{"up-to-cap", 163840, nil, false},
+		{"not-a-step", 10000, nil, true},
+		{"over-cap", 174080, nil, true},
+		{"shrink-below-used", 10240, used(100000), true},
+		{"shrink-usage-unknown", 10240, nil, false},
+		{"not-a-step-usage-unknown", 10000, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateServiceDiskSpace(plan, tt.additional, tt.used); (err != nil) != tt.wantErr {
+				t.Errorf("validateServiceDiskSpace() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_latestServiceMetricMax(t *testing.T) {
+	tests := []struct {
+		name   string
+		rows   [][]interface{}
+		want   float64
+		wantOk bool
+	}{
+		{"empty", nil, 0, false},
+		{"no-values", [][]interface{}{{"Date(2021,10,1,10,0,0)", nil}}, 0, false},
+		{"latest-row", [][]interface{}{{"Date(2021,10,1,10,0,0)", 10.0, 20.0}, {"Date(2021,10,1,10,1,0)", 30.0, 25.5}}, 30, true},
+		{"skip-empty-latest-row", [][]interface{}{{"Date(2021,10,1,10,0,0)", 10.0, 20.0}, {"Date(2021,10,1,10,1,0)", nil, nil}}, 20, true},
+		{"zero-usage", [][]interface{}{{"Date(2021,10,1,10,0,0)", 0.0}}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := latestServiceMetricMax(tt.rows)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("latestServiceMetricMax() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func Test_serviceDiskUsageAvailable(t *testing.T) {
+	tests := []struct {
+		name    string
+		service *aiven.Service
+		total   int
+		want    bool
+	}{
+		{"running", &aiven.Service{Name: "test-pg", State: "RUNNING"}, 81920, true},
+		{"powered-off", &aiven.Service{Name: "test-pg", State: "POWEROFF"}, 81920, false},
+		{"rebuilding", &aiven.Service{Name: "test-pg", State: "REBUILDING"}, 81920, false},
+		{"no-disk-space", &aiven.Service{Name: "test-pg", State: "RUNNING"}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := serviceDiskUsageAvailable(tt.service, tt.total); got != tt.want {
+				t.Errorf("serviceDiskUsageAvailable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccAivenService_additionalDiskSpace(t *testing.T) {
+	resourceName := "aiven_pg.bar"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenServiceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceAdditionalDiskSpaceResource(rName, 0),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "additional_disk_space", "0"),
+					resource.TestCheckResourceAttrSet(resourceName, "disk_space_cap"),
+					resource.TestCheckResourceAttr(resourceName, "state", "RUNNING"),
+				),
+			},
+			{
+				Config: testAccServiceAdditionalDiskSpaceResource(rName, 10240),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "additional_disk_space", "10240"),
+					resource.TestCheckResourceAttrSet(resourceName, "disk_space_used"),
+				),
+			},
+			{
+				Config:      testAccServiceAdditionalDiskSpaceResource(rName, 10000),
+				ExpectError: regexp.MustCompile("is not a multiple of the disk space step"),
+			},
+		},
+	})
+}
+
+func testAccServiceAdditionalDiskSpaceResource(name string, additionalDiskSpace int) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_pg" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "business-4"
+			service_name = "test-acc-sr-%s"
+			additional_disk_space = %d
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name, additionalDiskSpace)
+}
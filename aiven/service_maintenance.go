@@ -0,0 +1,209 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// serviceMaintenanceUpdate is a pending maintenance update of a service
+type serviceMaintenanceUpdate struct {
+	Description string  `json:"description"`
+	Deadline    *string `json:"deadline"`
+	StartAfter  *string `json:"start_after"`
+	StartAt     *string `json:"start_at"`
+}
+
+func serviceMaintenanceUpdatesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Pending maintenance updates of the service, they are applied during the next maintenance window or when `apply_pending_maintenance` is set.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"description": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Description of the update",
+				},
+				"deadline": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Time by which the update is applied at the latest, empty if the update has no deadline",
+				},
+				"start_after": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Time after which the update can be applied",
+				},
+				"start_at": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Time at which the update is scheduled to be applied automatically, empty if it is not scheduled",
+				},
+			},
+		},
+	}
+}
+
+// serviceMaintenanceUpdates returns the pending maintenance updates of a service
+func serviceMaintenanceUpdates(client *aiven.Client, projectName, serviceName string) ([]serviceMaintenanceUpdate, error) {
+	details, err := getServiceDetails(client, projectName, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	return details.Maintenance.Updates, nil
+}
+
+// serviceMaintenanceStart starts applying the pending maintenance updates of a service
+func serviceMaintenanceStart(client *aiven.Client, projectName, serviceName string) error {
+	path := clientRequestPath("project", projectName, "service", serviceName, "maintenance", "start")
+	if err := clientRequest(client, http.MethodPut, path, nil, nil); err != nil {
+		return fmt.Errorf("cannot start maintenance of service %s: %s", serviceName, err)
+	}
+
+	return nil
+}
+
+func flattenServiceMaintenanceUpdates(updates []serviceMaintenanceUpdate) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(updates))
+	for _, u := range updates {
+		result = append(result, map[string]interface{}{
+			"description": u.Description,
+			"deadline":    stringValue(u.Deadline),
+			"start_after": stringValue(u.StartAfter),
+			"start_at":    stringValue(u.StartAt),
+		})
+	}
+
+	return result
+}
+
+// resourceServiceApplyPendingMaintenance starts the pending maintenance updates of a service
+// when requested and waits for the service to be running again
+func resourceServiceApplyPendingMaintenance(ctx context.Context, d *schema.ResourceData, m interface{}) (*aiven.Service, error) {
+	client := m.(*providerMeta).client
+
+	if !d.Get("apply_pending_maintenance").(bool) || !d.Get("powered").(bool) {
+		return nil, nil
+	}
+
+	projectName, serviceName := splitResourceID2(d.Id())
+	updates, err := serviceMaintenanceUpdates(client, projectName, serviceName)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get maintenance updates of service %s: %s", serviceName, err)
+	}
+	startable := startableServiceMaintenanceUpdates(updates, time.Now())
+	if startable == 0 {
+		return nil, nil
+	}
+
+	log.Printf("[INFO] applying %d pending maintenance updates of service %s", startable, serviceName)
+	if err := serviceMaintenanceStart(client, projectName, serviceName); err != nil {
+		return nil, err
+	}
+
+	// the service is still running right after the maintenance is started, waiting for it to
+	// be running would return before the maintenance has even begun
+	conf := &resource.StateChangeConf{
+		Pending:    []string{"PENDING"},
+		Target:     []string{"STARTED"},
+		Refresh:    serviceMaintenanceStartedRefreshFunc(client, projectName, serviceName),
+		Delay:      10 * time.Second,
+		Timeout:    d.Timeout(schema.TimeoutUpdate),
+		MinTimeout: 2 * time.Second,
+	}
+	if _, err := conf.WaitForStateContext(ctx); err != nil {
+		return nil, fmt.Errorf("error waiting for the maintenance of service %s to start: %s", serviceName, err)
+	}
+
+	return resourceServiceWait(ctx, d, m, "maintenance")
+}
+
+// serviceMaintenanceStartedRefreshFunc reports the maintenance of a service as started
+func serviceMaintenanceStartedRefreshFunc(client *aiven.Client, projectName, serviceName string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		service, details, err := getService(client, projectName, serviceName)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if serviceMaintenanceStarted(service, details.Maintenance.Updates, time.Now()) {
+			return service, "STARTED", nil
+		}
+
+		return service, "PENDING", nil
+	}
+}
+
+// serviceMaintenanceStarted reports whether the maintenance of a service has started, the
+// service is no longer running or has no startable maintenance updates left
+func serviceMaintenanceStarted(service *aiven.Service, updates []serviceMaintenanceUpdate, now time.Time) bool {
+	return service.State != aivenTargetState || startableServiceMaintenanceUpdates(updates, now) == 0
+}
+
+// startableServiceMaintenanceUpdates returns the number of maintenance updates that can be
+// started now, updates with a later start_after are left for the maintenance window
+func startableServiceMaintenanceUpdates(updates []serviceMaintenanceUpdate, now time.Time) int {
+	n := 0
+	for _, u := range updates {
+		if serviceMaintenanceStartable(stringValue(u.StartAfter), now) {
+			n++
+		}
+	}
+
+	return n
+}
+
+// serviceMaintenanceStartable reports whether a maintenance update with the given start_after
+// can be started now
+func serviceMaintenanceStartable(startAfter string, now time.Time) bool {
+	if startAfter == "" {
+		return true
+	}
+
+	t, err := time.Parse(time.RFC3339, startAfter)
+	if err != nil {
+		return true
+	}
+
+	return !now.Before(t)
+}
+
+// resourceServiceMaintenanceCustomizeDiff plans an update of a powered on service with pending
+// maintenance updates that can be started now when they are applied by Terraform, the updates are
+// started during the apply
+func resourceServiceMaintenanceCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.Get("apply_pending_maintenance").(bool) {
+		return nil
+	}
+
+	if powered, ok := d.Get("powered").(bool); ok && !powered {
+		return nil
+	}
+
+	now := time.Now()
+	for _, u := range d.Get("maintenance_updates").([]interface{}) {
+		if u, ok := u.(map[string]interface{}); ok && serviceMaintenanceStartable(u["start_after"].(string), now) {
+			return d.SetNewComputed("maintenance_updates")
+		}
+	}
+
+	return nil
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func Test_flattenServiceMaintenanceUpdates(t *testing.T) {
+	deadline, startAfter := "2021-10-01T10:00:00Z", "2021-09-01T10:00:00Z"
+	updates := []serviceMaintenanceUpdate{
+		{Description: "Security update", Deadline: &deadline, StartAfter: &startAfter},
+		{Description: "Minor update"},
+	}
+
+	want := []map[string]interface{}{
+		{"description": "Security update", "deadline": deadline, "start_after": startAfter, "start_at": ""},
+		{"description": "Minor update", "deadline": "", "start_after": "", "start_at": ""},
+	}
+	if got := flattenServiceMaintenanceUpdates(updates); !reflect.DeepEqual(got, want) {
+		t.Errorf("flattenServiceMaintenanceUpdates() = %v, want %v", got, want)
+	}
+}
+
+func Test_startableServiceMaintenanceUpdates(t *testing.T) {
+	now := time.Date(2021, 11, 20, 12, 0, 0, 0, time.UTC)
+	past, future := "2021-11-19T12:00:00Z", "2021-11-21T12:00:00Z"
+
+	tests := []struct {
+		name    string
+		updates []serviceMaintenanceUpdate
+		want    int
+	}{
+		{
+			"none",
+			nil,
+			0,
+		},
+		{
+			"without start after",
+			[]serviceMaintenanceUpdate{{Description: "update"}},
+			1,
+		},
+		{
+			"started after",
+			[]serviceMaintenanceUpdate{{Description: "update", StartAfter: &past}},
+			1,
+		},
+		{
+			"not startable yet",
+			[]serviceMaintenanceUpdate{{Description: "update", StartAfter: &future}},
+			0,
+		},
+		{
+			"mixed",
+			[]serviceMaintenanceUpdate{{Description: "a", StartAfter: &past}, {Description: "b", StartAfter: &future}, {Description: "c"}},
+			2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := startableServiceMaintenanceUpdates(tt.updates, now); got != tt.want {
+				t.Errorf("startableServiceMaintenanceUpdates() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_serviceMaintenanceStarted(t *testing.T) {
+	now := time.Date(2021, 11, 20, 12, 0, 0, 0, time.UTC)
+	future := "2021-11-21T12:00:00Z"
+
+	tests := []struct {
+		name    string
+		state   string
+		updates []serviceMaintenanceUpdate
+		want    bool
+	}{
+		{"running-pending", "RUNNING", []serviceMaintenanceUpdate{{Description: "update"}}, false},
+		{"rebuilding-pending", "REBUILDING", []serviceMaintenanceUpdate{{Description: "update"}}, true},
+		{"running-done", "RUNNING", nil, true},
+		{"running-not-startable", "RUNNING", []serviceMaintenanceUpdate{{Description: "update", StartAfter: &future}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := serviceMaintenanceStarted(&aiven.Service{State: tt.state}, tt.updates, now); got != tt.want {
+				t.Errorf("serviceMaintenanceStarted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccAivenService_applyPendingMaintenance(t *testing.T) {
+	resourceName := "aiven_pg.bar"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenServiceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceApplyPendingMaintenanceResource(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "apply_pending_maintenance", "false"),
+					resource.TestCheckResourceAttrSet(resourceName, "maintenance_updates.#"),
+				),
+			},
+			{
+				Config: testAccServiceApplyPendingMaintenanceResource(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "apply_pending_maintenance", "true"),
+					resource.TestCheckResourceAttr(resourceName, "state", "RUNNING"),
+				),
+			},
+		},
+	})
+}
+
+func testAccServiceApplyPendingMaintenanceResource(name string, apply bool) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_pg" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-4"
+			service_name = "test-acc-sr-%s"
+			apply_pending_maintenance = %t
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name, apply)
+}
@@ -0,0 +1,212 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// hoursPerMonth is the average number of hours in a month used to estimate monthly prices
+const hoursPerMonth = 730
+
+// servicePlan is a plan of a service type available in a project, the service types and
+// plans are not covered by aiven-go-client
+type servicePlan struct {
+	ServicePlan     string `json:"service_plan"`
+	ServiceType     string `json:"service_type"`
+	NodeCount       int    `json:"node_count"`
+	DiskSpaceMB     int    `json:"disk_space_mb"`
+	DiskSpaceCapMB  int    `json:"disk_space_cap_mb"`
+	DiskSpaceStepMB int    `json:"disk_space_step_mb"`
+
+	Regions map[string]servicePlanRegion `json:"regions"`
+}
+
+// servicePlanRegion is the specification and the price of a plan in a cloud
+type servicePlanRegion struct {
+	DiskSpaceMB    int    `json:"disk_space_mb"`
+	DiskSpaceCapMB int    `json:"disk_space_cap_mb"`
+	NodeCPUCount   int    `json:"node_cpu_count"`
+	NodeMemoryMB   int    `json:"node_memory_mb"`
+	PriceUSD       string `json:"price_usd"`
+}
+
+// servicePlanCache caches the service types and plans available in the projects, the catalog
+// of a project is fetched once per provider run and shared by the refreshes and plans of all
+// the services of the project
+type servicePlanCache struct {
+	sync.Mutex
+	catalogs map[string]map[string][]servicePlan
+}
+
+func newServicePlanCache() *servicePlanCache {
+	return &servicePlanCache{catalogs: make(map[string]map[string][]servicePlan)}
+}
+
+// Load returns the plans of a service type available in a project, the catalog of the project
+// is fetched when it is not cached yet
+func (c *servicePlanCache) Load(client *aiven.Client, projectName, serviceType string) ([]servicePlan, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	catalog, ok := c.catalogs[projectName]
+	if !ok {
+		var err error
+		if catalog, err = getServiceCatalog(client, projectName); err != nil {
+			return nil, err
+		}
+		c.catalogs[projectName] = catalog
+	}
+
+	plans, ok := catalog[serviceType]
+	if !ok {
+		return nil, fmt.Errorf("service type %s is not available in project %s", serviceType, projectName)
+	}
+
+	return plans, nil
+}
+
+// getServiceCatalog returns the plans of the service types available in a project
+func getServiceCatalog(client *aiven.Client, projectName string) (map[string][]servicePlan, error) {
+	var r struct {
+		ServiceTypes map[string]struct {
+			ServicePlans []servicePlan `json:"service_plans"`
+		} `json:"service_types"`
+	}
+
+	path := clientRequestPath("project", projectName, "service_types")
+	if err := clientRequest(client, http.MethodGet, path, nil, &r); err != nil {
+		return nil, err
+	}
+
+	catalog := make(map[string][]servicePlan, len(r.ServiceTypes))
+	for serviceType, t := range r.ServiceTypes {
+		catalog[serviceType] = t.ServicePlans
+	}
+
+	return catalog, nil
+}
+
+// getServicePlans returns the plans of a service type available in a project from the
+// catalog cache of the provider
+func getServicePlans(m interface{}, projectName, serviceType string) ([]servicePlan, error) {
+	meta := m.(*providerMeta)
+
+	return meta.plans.Load(meta.client, projectName, serviceType)
+}
+
+// getServicePlan returns a plan of a service type available in a project
+func getServicePlan(m interface{}, projectName, serviceType, plan string) (*servicePlan, error) {
+	plans, err := getServicePlans(m, projectName, serviceType)
+	if err != nil {
+		return nil, err
+	}
+
+	p := findServicePlan(plans, plan)
+	if p == nil {
+		return nil, fmt.Errorf("plan %s of service type %s is not available in project %s", plan, serviceType, projectName)
+	}
+
+	return p, nil
+}
+
+// findServicePlan returns the plan with the given name or nil when it is not found
+func findServicePlan(plans []servicePlan, plan string) *servicePlan {
+	for i := range plans {
+		if plans[i].ServicePlan == plan {
+			return &plans[i]
+		}
+	}
+
+	return nil
+}
+
+func serviceCostEstimateSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Estimated price of the plan of the service in its cloud, updated when the plan or the cloud changes. Additional disk space and usage based charges are not included. See the `aiven_service_plans` data source for the prices of the other plans.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"hourly_price_usd": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Hourly price in US dollars",
+				},
+				"monthly_price_usd": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Monthly price in US dollars, estimated for 730 hours",
+				},
+			},
+		},
+	}
+}
+
+// resourceServiceCostEstimateCustomizeDiff plans the cost estimate of a service when its plan
+// or cloud changes, the service type of the service specific resources is given as it is not
+// known before creation
+func resourceServiceCostEstimateCustomizeDiff(serviceType string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+		if d.Id() != "" && !d.HasChange("plan") && !d.HasChange("cloud_name") {
+			return nil
+		}
+
+		serviceType := serviceType
+		if serviceType == "service" {
+			serviceType = d.Get("service_type").(string)
+		}
+
+		// the API defaults of the plan and the cloud are only known after apply
+		if !d.NewValueKnown("project") || !d.NewValueKnown("plan") || !d.NewValueKnown("cloud_name") ||
+			d.Get("plan").(string) == "" || d.Get("cloud_name").(string) == "" || serviceType == "" {
+			return d.SetNewComputed("cost_estimate")
+		}
+
+		plan, err := getServicePlan(m, d.Get("project").(string), serviceType, d.Get("plan").(string))
+		if err != nil {
+			log.Printf("[DEBUG] cannot estimate the cost of the service during plan: %s", err)
+			return d.SetNewComputed("cost_estimate")
+		}
+
+		return d.SetNew("cost_estimate", flattenServiceCostEstimate(plan, d.Get("cloud_name").(string)))
+	}
+}
+
+func flattenServiceCostEstimate(plan *servicePlan, cloud string) []map[string]interface{} {
+	region, ok := plan.Regions[cloud]
+	if !ok {
+		return []map[string]interface{}{}
+	}
+
+	hourly, monthly, err := servicePlanPrices(region)
+	if err != nil {
+		log.Printf("[DEBUG] invalid price of plan %s in cloud %s: %s", plan.ServicePlan, cloud, err)
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{
+		{
+			"hourly_price_usd":  hourly,
+			"monthly_price_usd": monthly,
+		},
+	}
+}
+
+// servicePlanPrices returns the hourly price of a plan in a cloud as given by the API and the
+// monthly price estimated from it
+func servicePlanPrices(region servicePlanRegion) (hourly, monthly string, err error) {
+	price, err := strconv.ParseFloat(region.PriceUSD, 64)
+	if err != nil {
+		return "", "", err
+	}
+
+	return region.PriceUSD, strconv.FormatFloat(price*hoursPerMonth, 'f', 2, 64), nil
+}
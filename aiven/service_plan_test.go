@@ -0,0 +1,113 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_findServicePlan(t *testing.T) {
+	plans := []servicePlan{
+		{ServicePlan: "startup-4", ServiceType: "pg", DiskSpaceMB: 81920},
+		{ServicePlan: "business-4", ServiceType: "pg", DiskSpaceMB: 81920, DiskSpaceCapMB: 245760, DiskSpaceStepMB: 10240},
+	}
+
+	tests := []struct {
+		name string
+		plan string
+		want *servicePlan
+	}{
+		{"found", "business-4", &servicePlan{ServicePlan: "business-4", ServiceType: "pg", DiskSpaceMB: 81920, DiskSpaceCapMB: 245760, DiskSpaceStepMB: 10240}},
+		{"unknown-plan", "premium-4", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := findServicePlan(plans, tt.plan); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("findServicePlan() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_servicePlanCache_Load(t *testing.T) {
+	// the client is not used as the catalog of the project is already cached
+	cache := newServicePlanCache()
+	cache.catalogs["foo"] = map[string][]servicePlan{
+		"pg": {{ServicePlan: "startup-4", ServiceType: "pg"}},
+	}
+
+	tests := []struct {
+		name        string
+		serviceType string
+		want        []servicePlan
+		wantErr     bool
+	}{
+		{"cached", "pg", []servicePlan{{ServicePlan: "startup-4", ServiceType: "pg"}}, false},
+		{"unknown-service-type", "kafka", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cache.Load(nil, "foo", tt.serviceType)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Load() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_servicePlanPrices(t *testing.T) {
+	tests := []struct {
+		name        string
+		priceUSD    string
+		wantHourly  string
+		wantMonthly string
+		wantErr     bool
+	}{
+		{"price", "0.1370", "0.1370", "100.01", false},
+		{"free", "0", "0", "0.00", false},
+		{"rounded", "0.0001", "0.0001", "0.07", false},
+		{"empty", "", "", "", true},
+		{"invalid", "abc", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hourly, monthly, err := servicePlanPrices(servicePlanRegion{PriceUSD: tt.priceUSD})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("servicePlanPrices() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if hourly != tt.wantHourly || monthly != tt.wantMonthly {
+				t.Errorf("servicePlanPrices() = %v, %v, want %v, %v", hourly, monthly, tt.wantHourly, tt.wantMonthly)
+			}
+		})
+	}
+}
+
+func Test_flattenServiceCostEstimate(t *testing.T) {
+	plan := &servicePlan{
+		ServicePlan: "premium-4",
+		Regions: map[string]servicePlanRegion{
+			"aws-eu-west-1":       {PriceUSD: "0.4110"},
+			"google-europe-west1": {PriceUSD: "invalid"},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		cloud string
+		want  []map[string]interface{}
+	}{
+		{"cloud", "aws-eu-west-1", []map[string]interface{}{{"hourly_price_usd": "0.4110", "monthly_price_usd": "300.03"}}},
+		{"unknown-cloud", "azure-westeurope", []map[string]interface{}{}},
+		{"invalid-price", "google-europe-west1", []map[string]interface{}{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := flattenServiceCostEstimate(plan, tt.cloud); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("flattenServiceCostEstimate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
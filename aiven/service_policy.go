@@ -0,0 +1,292 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/aiven/terraform-provider-aiven/aiven/templates"
+	"github.com/aiven/terraform-provider-aiven/pkg/ipfilter"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// servicePolicy holds the guardrails of the provider `policy` block checked when services are planned
+type servicePolicy struct {
+	allowedClouds      []string
+	allowedPlans       map[string][]string
+	productionTags     map[string]string
+	forbidOpenIPFilter bool
+}
+
+func servicePolicySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Guardrails for all services managed by the provider, violations are reported as errors when the services are planned. Existing services are only checked when the checked attributes change.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"allowed_clouds": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Clouds the services can run in, `*` matches any characters, e.g. `aws-eu-*`. Services without `cloud_name` are checked against the default cloud of their project. All clouds are allowed when not set.",
+				},
+				"allowed_plan": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Description: "Plans the services of a type can use, all plans are allowed for service types without a block. Services of a type with a block must set `plan`.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"service_type": {
+								Type:        schema.TypeString,
+								Required:    true,
+								Description: "Service type code, e.g. `pg`",
+							},
+							"plans": {
+								Type:        schema.TypeSet,
+								Required:    true,
+								Elem:        &schema.Schema{Type: schema.TypeString},
+								Description: "Plans the services of the type can use, `*` matches any characters, e.g. `startup-*`",
+							},
+						},
+					},
+				},
+				"production_tags": {
+					Type:        schema.TypeMap,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Tags marking production services, including the default tags of the provider. Services with any of the tags must have `termination_protection` enabled.",
+				},
+				"forbid_open_ip_filter": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: complex("Reject services accepting connections from any address, i.e. with `0.0.0.0/0` or `::/0` in `ip_filter` or without an IP filter.").defaultValue(false).build(),
+				},
+			},
+		},
+	}
+}
+
+// readServicePolicy reads the `policy` block of the provider, the policy is nil when the block
+// is not set
+func readServicePolicy(d *schema.ResourceData) (*servicePolicy, error) {
+	if _, ok := d.GetOk("policy.0"); !ok {
+		return nil, nil
+	}
+
+	p := &servicePolicy{
+		allowedClouds:      flattenToString(d.Get("policy.0.allowed_clouds").(*schema.Set).List()),
+		allowedPlans:       make(map[string][]string),
+		productionTags:     make(map[string]string),
+		forbidOpenIPFilter: d.Get("policy.0.forbid_open_ip_filter").(bool),
+	}
+
+	for _, v := range d.Get("policy.0.allowed_plan").(*schema.Set).List() {
+		a := v.(map[string]interface{})
+		serviceType := a["service_type"].(string)
+		p.allowedPlans[serviceType] = append(p.allowedPlans[serviceType], flattenToString(a["plans"].(*schema.Set).List())...)
+	}
+
+	for k, v := range d.Get("policy.0.production_tags").(map[string]interface{}) {
+		p.productionTags[k] = v.(string)
+	}
+
+	patterns := append([]string{}, p.allowedClouds...)
+	for _, plans := range p.allowedPlans {
+		patterns = append(patterns, plans...)
+	}
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q in policy: %w", pattern, err)
+		}
+	}
+
+	return p, nil
+}
+
+// resourceServicePolicyCustomizeDiff rejects services violating the policy of the provider, the
+// service type of the service specific resources is given as it is not known before creation
+func resourceServicePolicyCustomizeDiff(serviceType string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+		p := m.(*providerMeta).policy
+		if p == nil {
+			return nil
+		}
+
+		serviceType := serviceType
+		if serviceType == "service" {
+			serviceType = d.Get("service_type").(string)
+		}
+
+		changed := func(keys ...string) bool {
+			for _, k := range keys {
+				if !d.NewValueKnown(k) {
+					return false
+				}
+			}
+			if d.Id() == "" {
+				return true
+			}
+			for _, k := range keys {
+				if d.HasChange(k) {
+					return true
+				}
+			}
+			return false
+		}
+
+		var violations []string
+
+		if changed("cloud_name") {
+			cloud := d.Get("cloud_name").(string)
+			// services without a cloud are created in the default cloud of the project
+			if cloud == "" && len(p.allowedClouds) > 0 && d.NewValueKnown("project") {
+				cloud = servicePolicyProjectDefaultCloud(m, d.Get("project").(string))
+			}
+
+			if err := p.checkCloud(cloud); err != nil {
+				violations = append(violations, err.Error())
+			}
+		}
+
+		if changed("plan") {
+			if err := p.checkPlan(serviceType, d.Get("plan").(string)); err != nil {
+				violations = append(violations, err.Error())
+			}
+		}
+
+		if changed("tags_all", "termination_protection") {
+			err := p.checkTerminationProtection(d.Get("tags_all").(map[string]interface{}), d.Get("termination_protection").(bool))
+			if err != nil {
+				violations = append(violations, err.Error())
+			}
+		}
+
+		userConfigKey := serviceType + "_user_config"
+		if p.forbidOpenIPFilter && serviceTypeHasIPFilter(serviceType) && changed(userConfigKey) {
+			networks, _ := d.Get(userConfigKey + ".0.ip_filter").([]interface{})
+			var objects []interface{}
+			if s, ok := d.Get(userConfigKey + ".0.ip_filter_object").(*schema.Set); ok {
+				objects = s.List()
+			}
+
+			if err := checkOpenIPFilter(ipfilter.Merge(networks, objects)); err != nil {
+				violations = append(violations, err.Error())
+			}
+		}
+
+		if len(violations) > 0 {
+			return fmt.Errorf("service %s violates the policy of the provider: %s",
+				d.Get("service_name"), strings.Join(violations, "; "))
+		}
+
+		return nil
+	}
+}
+
+// servicePolicyProjectDefaultCloud returns the default cloud of a project or an empty string
+// when it is not known
+func servicePolicyProjectDefaultCloud(m interface{}, projectName string) string {
+	project, err := m.(*providerMeta).client.Projects.Get(projectName)
+	if err != nil {
+		log.Printf("[DEBUG] cannot get the default cloud of project %s: %s", projectName, err)
+		return ""
+	}
+
+	return project.DefaultCloud
+}
+
+// checkCloud checks that a cloud is allowed by the policy, an unknown cloud is not allowed when
+// the clouds are restricted
+func (p *servicePolicy) checkCloud(cloud string) error {
+	if len(p.allowedClouds) == 0 || matchAnyPattern(p.allowedClouds, cloud) {
+		return nil
+	}
+	if cloud == "" {
+		return fmt.Errorf("cloud_name must be set as the cloud of the service is not known, allowed clouds are %s",
+			strings.Join(p.allowedClouds, ", "))
+	}
+
+	return fmt.Errorf("cloud %s is not allowed, allowed clouds are %s", cloud, strings.Join(p.allowedClouds, ", "))
+}
+
+// checkPlan checks that a plan is allowed for a service type by the policy, the plan must be set
+// when the plans of the service type are restricted
+func (p *servicePolicy) checkPlan(serviceType, plan string) error {
+	plans, ok := p.allowedPlans[serviceType]
+	if !ok || matchAnyPattern(plans, plan) {
+		return nil
+	}
+	if plan == "" {
+		return fmt.Errorf("plan must be set for %s services, allowed plans are %s", serviceType, strings.Join(plans, ", "))
+	}
+
+	return fmt.Errorf("plan %s is not allowed for %s services, allowed plans are %s", plan, serviceType, strings.Join(plans, ", "))
+}
+
+// checkTerminationProtection checks that the services with a production tag have termination
+// protection enabled
+func (p *servicePolicy) checkTerminationProtection(tags map[string]interface{}, terminationProtection bool) error {
+	if terminationProtection {
+		return nil
+	}
+
+	var matching []string
+	for k, v := range p.productionTags {
+		if t, ok := tags[k]; ok && t.(string) == v {
+			matching = append(matching, fmt.Sprintf("%s=%s", k, v))
+		}
+	}
+	if len(matching) == 0 {
+		return nil
+	}
+	sort.Strings(matching)
+
+	return fmt.Errorf("termination_protection must be enabled for services tagged %s", strings.Join(matching, ", "))
+}
+
+// checkOpenIPFilter rejects an IP filter allowing connections from any address, services without
+// an IP filter accept connections from any address
+func checkOpenIPFilter(entries []interface{}) error {
+	if len(entries) == 0 {
+		return fmt.Errorf("ip_filter must be set, services without an IP filter accept connections from %s", ipfilter.DefaultNetwork)
+	}
+
+	for _, e := range entries {
+		if n := ipfilter.Canonical(ipfilter.Network(e)); n == ipfilter.DefaultNetwork || n == "::/0" {
+			return fmt.Errorf("ip_filter must not contain %s", n)
+		}
+	}
+
+	return nil
+}
+
+// serviceTypeHasIPFilter reports whether the user configuration of a service type has an IP filter
+func serviceTypeHasIPFilter(serviceType string) bool {
+	c, ok := templates.GetUserConfigSchema("service")[serviceType].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	properties, _ := c["properties"].(map[string]interface{})
+	_, ok = properties["ip_filter"]
+
+	return ok
+}
+
+// matchAnyPattern reports whether a value matches any of the patterns, patterns are validated
+// when the provider is configured
+func matchAnyPattern(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, value); ok {
+			return true
+		}
+	}
+
+	return false
+}
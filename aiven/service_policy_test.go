@@ -0,0 +1,188 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func Test_readServicePolicy(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"policy": []interface{}{map[string]interface{}{
+			"allowed_clouds": []interface{}{"aws-eu-*"},
+			"allowed_plan": []interface{}{
+				map[string]interface{}{"service_type": "pg", "plans": []interface{}{"business-*"}},
+			},
+			"production_tags":       map[string]interface{}{"environment": "production"},
+			"forbid_open_ip_filter": true,
+		}},
+	})
+
+	p, err := readServicePolicy(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &servicePolicy{
+		allowedClouds:      []string{"aws-eu-*"},
+		allowedPlans:       map[string][]string{"pg": {"business-*"}},
+		productionTags:     map[string]string{"environment": "production"},
+		forbidOpenIPFilter: true,
+	}
+	if !reflect.DeepEqual(p, want) {
+		t.Errorf("readServicePolicy() = %v, want %v", p, want)
+	}
+
+	d = schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"policy": []interface{}{map[string]interface{}{"allowed_clouds": []interface{}{"aws-["}}},
+	})
+	if _, err := readServicePolicy(d); err == nil {
+		t.Errorf("readServicePolicy() expected an error for an invalid pattern")
+	}
+
+	d = schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{})
+	if p, err := readServicePolicy(d); p != nil || err != nil {
+		t.Errorf("readServicePolicy() = %v, %v, want no policy", p, err)
+	}
+}
+
+func Test_servicePolicy(t *testing.T) {
+	p := &servicePolicy{
+		allowedClouds:  []string{"aws-eu-*", "google-europe-west1"},
+		allowedPlans:   map[string][]string{"pg": {"business-*", "premium-*"}},
+		productionTags: map[string]string{"environment": "production"},
+	}
+
+	tests := []struct {
+		name    string
+		check   func() error
+		wantErr bool
+	}{
+		{"allowed cloud", func() error { return p.checkCloud("aws-eu-west-1") }, false},
+		{"allowed exact cloud", func() error { return p.checkCloud("google-europe-west1") }, false},
+		{"forbidden cloud", func() error { return p.checkCloud("aws-us-east-1") }, true},
+		{"unknown cloud", func() error { return p.checkCloud("") }, true},
+		{"unknown cloud without allowed clouds", func() error { return (&servicePolicy{}).checkCloud("") }, false},
+		{"allowed plan", func() error { return p.checkPlan("pg", "business-4") }, false},
+		{"forbidden plan", func() error { return p.checkPlan("pg", "hobbyist") }, true},
+		{"service type without plans", func() error { return p.checkPlan("kafka", "startup-2") }, false},
+		{"unknown plan", func() error { return p.checkPlan("pg", "") }, true},
+		{"unknown plan of service type without plans", func() error { return p.checkPlan("kafka", "") }, false},
+		{
+			"production without termination protection",
+			func() error {
+				return p.checkTerminationProtection(map[string]interface{}{"environment": "production"}, false)
+			},
+			true,
+		},
+		{
+			"production with termination protection",
+			func() error {
+				return p.checkTerminationProtection(map[string]interface{}{"environment": "production"}, true)
+			},
+			false,
+		},
+		{
+			"not production",
+			func() error {
+				return p.checkTerminationProtection(map[string]interface{}{"environment": "staging"}, false)
+			},
+			false,
+		},
+		{"restricted ip filter", func() error { return checkOpenIPFilter([]interface{}{"10.0.0.0/8"}) }, false},
+		{"open ip filter", func() error { return checkOpenIPFilter([]interface{}{"10.0.0.0/8", "0.0.0.0/0"}) }, true},
+		{
+			"open ipv6 ip filter object",
+			func() error {
+				return checkOpenIPFilter([]interface{}{map[string]interface{}{"network": "::/0", "description": "any"}})
+			},
+			true,
+		},
+		{"no ip filter", func() error { return checkOpenIPFilter(nil) }, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.check(); (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAccAivenService_policy(t *testing.T) {
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenServiceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccServicePolicyResource(rName, "aws-us-east-1", "startup-4", "staging", `["10.0.0.0/8"]`),
+				ExpectError: regexp.MustCompile("cloud aws-us-east-1 is not allowed"),
+			},
+			{
+				Config:      testAccServicePolicyResource(rName, "google-europe-west1", "hobbyist", "staging", `["10.0.0.0/8"]`),
+				ExpectError: regexp.MustCompile("plan hobbyist is not allowed for pg services"),
+			},
+			{
+				Config:      testAccServicePolicyResource(rName, "google-europe-west1", "", "staging", `["10.0.0.0/8"]`),
+				ExpectError: regexp.MustCompile("plan must be set for pg services"),
+			},
+			{
+				Config:      testAccServicePolicyResource(rName, "google-europe-west1", "startup-4", "production", `["10.0.0.0/8"]`),
+				ExpectError: regexp.MustCompile("termination_protection must be enabled"),
+			},
+			{
+				Config:      testAccServicePolicyResource(rName, "google-europe-west1", "startup-4", "staging", `["0.0.0.0/0"]`),
+				ExpectError: regexp.MustCompile("ip_filter must not contain 0.0.0.0/0"),
+			},
+		},
+	})
+}
+
+func testAccServicePolicyResource(name, cloud, plan, environment, ipFilter string) string {
+	return fmt.Sprintf(`
+		provider "aiven" {
+			policy {
+				allowed_clouds = ["google-europe-*"]
+				production_tags = {
+					environment = "production"
+				}
+				forbid_open_ip_filter = true
+
+				allowed_plan {
+					service_type = "pg"
+					plans = ["startup-*", "business-*"]
+				}
+			}
+		}
+
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_pg" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "%s"
+			plan = "%s"
+			service_name = "test-acc-sr-%s"
+
+			tag {
+				key = "environment"
+				value = "%s"
+			}
+
+			pg_user_config {
+				ip_filter = %s
+			}
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), cloud, plan, name, environment, ipFilter)
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var serviceTagKeyRegexp = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_.:-]{0,63}$`)
+
+func serviceTagSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Description: "Tags of the service, used for example to allocate the costs of the service. Tags given in the `default_tags` block of the provider are added to the service unless the same key is set here.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"key": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringMatch(serviceTagKeyRegexp, "must start with a letter and contain only letters, numbers and the characters _.:-"),
+					Description:  complex("Service tag key.").maxLen(64).build(),
+				},
+				"value": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringLenBetween(0, 64),
+					Description:  complex("Service tag value.").maxLen(64).build(),
+				},
+			},
+		},
+	}
+}
+
+func serviceTagsAllSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeMap,
+		Computed:    true,
+		Description: "All tags of the service, including the default tags of the provider.",
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	}
+}
+
+// validateServiceTags checks tags that are not validated by the tag schema
+func validateServiceTags(tags map[string]string) error {
+	for k, v := range tags {
+		if !serviceTagKeyRegexp.MatchString(k) {
+			return fmt.Errorf("invalid tag key %q: must start with a letter and contain at most 64 letters, numbers and the characters _.:-", k)
+		}
+		if len(v) > 64 {
+			return fmt.Errorf("invalid value of tag %q: must be at most 64 characters long", k)
+		}
+	}
+
+	return nil
+}
+
+// validateServiceTagKeys checks that the tags of a service do not set the same key twice, the
+// tags are a set of key value pairs so only the values would differ
+func validateServiceTagKeys(tags *schema.Set) error {
+	keys := make(map[string]bool, tags.Len())
+	for _, t := range tags.List() {
+		key := t.(map[string]interface{})["key"].(string)
+		if keys[key] {
+			return fmt.Errorf("tag %q is set more than once", key)
+		}
+		keys[key] = true
+	}
+
+	return nil
+}
+
+// mergeServiceTags merges the tags of a service on top of the default tags of the provider
+func mergeServiceTags(defaultTags map[string]string, tags *schema.Set) map[string]string {
+	merged := make(map[string]string, len(defaultTags)+tags.Len())
+	for k, v := range defaultTags {
+		merged[k] = v
+	}
+	for _, t := range tags.List() {
+		tag := t.(map[string]interface{})
+		merged[tag["key"].(string)] = tag["value"].(string)
+	}
+
+	return merged
+}
+
+// flattenServiceTags returns the tags of a service except the default tags of the provider that
+// are not set on the resource itself
+func flattenServiceTags(tags, defaultTags map[string]string, configured *schema.Set) []map[string]interface{} {
+	keys := make(map[string]bool)
+	for _, t := range configured.List() {
+		keys[t.(map[string]interface{})["key"].(string)] = true
+	}
+
+	result := make([]map[string]interface{}, 0, len(tags))
+	for k, v := range tags {
+		if dv, ok := defaultTags[k]; ok && dv == v && !keys[k] {
+			continue
+		}
+
+		result = append(result, map[string]interface{}{
+			"key":   k,
+			"value": v,
+		})
+	}
+
+	return result
+}
+
+// setServiceTags replaces the tags of a service, the request is not covered by aiven-go-client
+func setServiceTags(client *aiven.Client, projectName, serviceName string, tags map[string]string) error {
+	path := clientRequestPath("project", projectName, "service", serviceName, "tags")
+	if err := clientRequest(client, http.MethodPut, path, map[string]interface{}{"tags": tags}, nil); err != nil {
+		return fmt.Errorf("cannot set tags of service %s: %s", serviceName, err)
+	}
+
+	return nil
+}
+
+// resourceServiceUpdateTags sets the tags of a service including the default tags of the provider
+func resourceServiceUpdateTags(d *schema.ResourceData, m interface{}) error {
+	tags := mergeServiceTags(m.(*providerMeta).defaultTags, d.Get("tag").(*schema.Set))
+
+	return setServiceTags(m.(*providerMeta).client, d.Get("project").(string), d.Get("service_name").(string), tags)
+}
+
+// copyServiceTagsFromAPIResponseToTerraform sets the tags of a service
+func copyServiceTagsFromAPIResponseToTerraform(d *schema.ResourceData, m interface{}, tags map[string]string) error {
+	if tags == nil {
+		tags = make(map[string]string)
+	}
+
+	defaultTags := m.(*providerMeta).defaultTags
+	if err := d.Set("tag", flattenServiceTags(tags, defaultTags, d.Get("tag").(*schema.Set))); err != nil {
+		return err
+	}
+
+	return d.Set("tags_all", tags)
+}
+
+// resourceServiceTagsCustomizeDiff plans the tags of a service including the default tags of
+// the provider, changes of the default tags show up as changes of `tags_all`
+func resourceServiceTagsCustomizeDiff(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("tag") {
+		return d.SetNewComputed("tags_all")
+	}
+
+	if err := validateServiceTagKeys(d.Get("tag").(*schema.Set)); err != nil {
+		return err
+	}
+
+	tags := make(map[string]interface{})
+	for k, v := range mergeServiceTags(m.(*providerMeta).defaultTags, d.Get("tag").(*schema.Set)) {
+		tags[k] = v
+	}
+
+	if reflect.DeepEqual(d.Get("tags_all").(map[string]interface{}), tags) {
+		return nil
+	}
+
+	return d.SetNew("tags_all", tags)
+}
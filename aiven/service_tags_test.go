@@ -0,0 +1,170 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func testServiceTagSet(tags map[string]string) *schema.Set {
+	s := schema.NewSet(schema.HashResource(serviceTagSchema().Elem.(*schema.Resource)), nil)
+	for k, v := range tags {
+		s.Add(map[string]interface{}{"key": k, "value": v})
+	}
+
+	return s
+}
+
+func Test_mergeServiceTags(t *testing.T) {
+	defaultTags := map[string]string{"team": "data", "environment": "production"}
+
+	tests := []struct {
+		name string
+		tags map[string]string
+		want map[string]string
+	}{
+		{"defaults-only", nil, map[string]string{"team": "data", "environment": "production"}},
+		{"add", map[string]string{"cost-center": "42"}, map[string]string{"team": "data", "environment": "production", "cost-center": "42"}},
+		{"override", map[string]string{"environment": "staging"}, map[string]string{"team": "data", "environment": "staging"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mergeServiceTags(defaultTags, testServiceTagSet(tt.tags)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mergeServiceTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_flattenServiceTags(t *testing.T) {
+	defaultTags := map[string]string{"team": "data", "environment": "production"}
+
+	tests := []struct {
+		name       string
+		tags       map[string]string
+		configured map[string]string
+		want       map[string]string
+	}{
+		{"defaults-hidden", map[string]string{"team": "data", "environment": "production"}, nil, map[string]string{}},
+		{"configured-default-kept", map[string]string{"team": "data", "environment": "production"}, map[string]string{"team": "data"}, map[string]string{"team": "data"}},
+		{"changed-default-shown", map[string]string{"team": "web", "environment": "production"}, nil, map[string]string{"team": "web"}},
+		{"own-tag", map[string]string{"team": "data", "cost-center": "42"}, map[string]string{"cost-center": "42"}, map[string]string{"cost-center": "42"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make(map[string]string)
+			for _, tag := range flattenServiceTags(tt.tags, defaultTags, testServiceTagSet(tt.configured)) {
+				got[tag["key"].(string)] = tag["value"].(string)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("flattenServiceTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_validateServiceTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		tags    map[string]string
+		wantErr bool
+	}{
+		{"valid", map[string]string{"team": "data", "cost.center:id": "42"}, false},
+		{"key-starts-with-number", map[string]string{"1team": "data"}, true},
+		{"key-with-space", map[string]string{"cost center": "42"}, true},
+		{"value-too-long", map[string]string{"team": string(make([]byte, 65))}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateServiceTags(tt.tags); (err != nil) != tt.wantErr {
+				t.Errorf("validateServiceTags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_validateServiceTagKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		tags    []map[string]interface{}
+		wantErr bool
+	}{
+		{"empty", nil, false},
+		{"unique", []map[string]interface{}{{"key": "team", "value": "data"}, {"key": "environment", "value": "production"}}, false},
+		{"duplicate", []map[string]interface{}{{"key": "team", "value": "data"}, {"key": "team", "value": "web"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tags := testServiceTagSet(nil)
+			for _, tag := range tt.tags {
+				tags.Add(tag)
+			}
+			if err := validateServiceTagKeys(tags); (err != nil) != tt.wantErr {
+				t.Errorf("validateServiceTagKeys() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAccAivenService_tags(t *testing.T) {
+	resourceName := "aiven_pg.bar"
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAivenServiceResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceTagsResource(rName, "team", "production"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tag.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.environment", "production"),
+				),
+			},
+			{
+				Config: testAccServiceTagsResource(rName, "team", "staging"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tags_all.environment", "staging"),
+				),
+			},
+			{
+				Config:      testAccServiceTagsResource(rName, "environment", "staging"),
+				ExpectError: regexp.MustCompile(`tag "environment" is set more than once`),
+			},
+		},
+	})
+}
+
+func testAccServiceTagsResource(name, key, environment string) string {
+	return fmt.Sprintf(`
+		data "aiven_project" "foo" {
+			project = "%s"
+		}
+
+		resource "aiven_pg" "bar" {
+			project = data.aiven_project.foo.project
+			cloud_name = "google-europe-west1"
+			plan = "startup-4"
+			service_name = "test-acc-sr-%s"
+
+			tag {
+				key = "%s"
+				value = "data"
+			}
+
+			tag {
+				key = "environment"
+				value = "%s"
+			}
+		}
+		`, os.Getenv("AIVEN_PROJECT_NAME"), name, key, environment)
+}
@@ -0,0 +1,262 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// serviceUpgradeDryRunTimeout is the time a pre-flight check is allowed to take during plan,
+// where resource timeouts are not available
+const serviceUpgradeDryRunTimeout = 5 * time.Minute
+
+// serviceVersionUserConfigKeys maps service types to the user configuration option holding
+// the major version of the service
+var serviceVersionUserConfigKeys = map[string]string{
+	ServiceTypePG:            "pg_version",
+	ServiceTypeMySQL:         "mysql_version",
+	ServiceTypeCassandra:     "cassandra_version",
+	ServiceTypeElasticsearch: "elasticsearch_version",
+	ServiceTypeOpensearch:    "opensearch_version",
+	ServiceTypeKafka:         "kafka_version",
+	ServiceTypeM3:            "m3_version",
+	ServiceTypeM3Aggregator:  "m3_version",
+	ServiceTypeFlink:         "flink_version",
+}
+
+// serviceUpgradeCheckTypes lists the service types supporting the `upgrade_check` service task
+var serviceUpgradeCheckTypes = map[string]bool{
+	ServiceTypePG: true,
+}
+
+func serviceUpgradeDryRunSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: complex("Runs the upgrade check of a major version upgrade of the service already during plan, failing the plan when the upgrade is not possible. The check creates an `upgrade_check` task on the service during plan, the upgrade itself is done during apply.").defaultValue(false).build(),
+	}
+}
+
+// serviceVersionChanger is implemented by both schema.ResourceData and schema.ResourceDiff
+type serviceVersionChanger interface {
+	Get(string) interface{}
+	GetChange(string) (interface{}, interface{})
+	HasChange(string) bool
+}
+
+// serviceVersionChange returns the current and the target major version of a service when the
+// version is being changed
+func serviceVersionChange(d serviceVersionChanger) (serviceType, from, to string, ok bool) {
+	serviceType = d.Get("service_type").(string)
+
+	key, supported := serviceVersionUserConfigKeys[serviceType]
+	if !supported {
+		return serviceType, "", "", false
+	}
+
+	k := fmt.Sprintf("%s_user_config.0.%s", serviceType, key)
+	if !d.HasChange(k) {
+		return serviceType, "", "", false
+	}
+
+	o, n := d.GetChange(k)
+	from, to = o.(string), n.(string)
+
+	// a version that is not set, or was not known before, is left to the API defaults
+	if from == "" || to == "" {
+		return serviceType, "", "", false
+	}
+
+	return serviceType, from, to, true
+}
+
+// serviceUpgradeCheck runs the pre-flight checks of a major version upgrade, the local
+// checks for all service types and the `upgrade_check` service task where available
+func serviceUpgradeCheck(
+	ctx context.Context,
+	client *aiven.Client,
+	projectName, serviceName, serviceType, from, to string,
+	timeout time.Duration,
+) (string, error) {
+	if c, ok := compareServiceVersions(from, to); ok && c > 0 {
+		return "", fmt.Errorf("%s service %s cannot be downgraded from version %s to %s", serviceType, serviceName, from, to)
+	}
+
+	if !serviceUpgradeCheckTypes[serviceType] {
+		log.Printf("[DEBUG] no upgrade check available for %s services, upgrading %s from %s to %s",
+			serviceType, serviceName, from, to)
+		return "", nil
+	}
+
+	t, err := client.ServiceTask.Create(projectName, serviceName, aiven.ServiceTaskRequest{
+		TargetVersion: to,
+		TaskType:      "upgrade_check",
+	})
+	if err != nil {
+		return "", fmt.Errorf("cannot create %s upgrade check task: %s", serviceType, err)
+	}
+
+	w := &ServiceTaskWaiter{
+		Client:      client,
+		Project:     projectName,
+		ServiceName: serviceName,
+		TaskId:      t.Task.Id,
+	}
+
+	taskI, err := w.Conf(timeout).WaitForStateContext(ctx)
+	if err != nil {
+		return "", fmt.Errorf("error waiting for Aiven service task to be DONE: %s", err)
+	}
+
+	task := taskI.(*aiven.ServiceTaskResponse)
+	if !*task.Task.Success {
+		return task.Task.Result, fmt.Errorf("%s service upgrade check error, version upgrade from %s to %s, result: %s",
+			serviceType, from, to, task.Task.Result)
+	}
+
+	log.Printf("[DEBUG] %s service upgrade check result: %s", serviceType, task.Task.Result)
+
+	return task.Task.Result, nil
+}
+
+// resourceServiceUpgradeCheck runs the pre-flight checks before a service update changing
+// the major version of the service, a successful check result is returned as a warning
+func resourceServiceUpgradeCheck(ctx context.Context, d *schema.ResourceData, m interface{}) (bool, diag.Diagnostics) {
+	serviceType, from, to, ok := serviceVersionChange(d)
+	if !ok {
+		return false, nil
+	}
+
+	projectName, serviceName := splitResourceID2(d.Id())
+	result, err := serviceUpgradeCheck(ctx, m.(*providerMeta).client, projectName, serviceName, serviceType, from, to,
+		d.Timeout(schema.TimeoutDefault))
+	if err != nil {
+		return true, diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("%s service %s cannot be upgraded from version %s to %s", serviceType, serviceName, from, to),
+			Detail:   err.Error(),
+		}}
+	}
+
+	if result == "" {
+		return true, nil
+	}
+
+	return true, diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("%s service %s upgrade check from version %s to %s passed", serviceType, serviceName, from, to),
+		Detail:   result,
+	}}
+}
+
+// resourceServiceUpgradeCustomizeDiff runs the pre-flight checks of a major version upgrade already
+// during plan when `upgrade_dry_run` is enabled, only the service types with an upgrade check
+// task have the field
+func resourceServiceUpgradeCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if dryRun, _ := d.Get("upgrade_dry_run").(bool); !dryRun {
+		return nil
+	}
+
+	// the field of the generic service resource is not limited to the supported service types
+	if serviceType := d.Get("service_type").(string); serviceType != "" && !serviceUpgradeCheckTypes[serviceType] {
+		return fmt.Errorf("upgrade_dry_run is not supported by %s services", serviceType)
+	}
+
+	if d.Id() == "" {
+		return nil
+	}
+
+	serviceType, from, to, ok := serviceVersionChange(d)
+	if !ok {
+		return nil
+	}
+
+	projectName, serviceName := splitResourceID2(d.Id())
+	_, err := serviceUpgradeCheck(ctx, m.(*providerMeta).client, projectName, serviceName, serviceType, from, to,
+		serviceUpgradeDryRunTimeout)
+
+	return err
+}
+
+// compareServiceVersions compares two dotted numeric versions, it returns false when either
+// of the versions is not numeric
+func compareServiceVersions(a, b string) (int, bool) {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		var err error
+
+		if i < len(as) {
+			if x, err = strconv.Atoi(as[i]); err != nil {
+				return 0, false
+			}
+		}
+		if i < len(bs) {
+			if y, err = strconv.Atoi(bs[i]); err != nil {
+				return 0, false
+			}
+		}
+
+		switch {
+		case x < y:
+			return -1, true
+		case x > y:
+			return 1, true
+		}
+	}
+
+	return 0, true
+}
+
+// ServiceTaskWaiter is used to refresh the Aiven Service Task endpoints when
+// provisioning.
+type ServiceTaskWaiter struct {
+	Client      *aiven.Client
+	Project     string
+	ServiceName string
+	TaskId      string
+}
+
+// RefreshFunc will call the Aiven client and refresh its state.
+func (w *ServiceTaskWaiter) RefreshFunc() resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		t, err := w.Client.ServiceTask.Get(
+			w.Project,
+			w.ServiceName,
+			w.TaskId,
+		)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if t.Task.Success == nil {
+			return nil, "IN_PROGRESS", nil
+		}
+
+		return t, "DONE", nil
+	}
+}
+
+// Conf sets up the configuration to refresh.
+func (w *ServiceTaskWaiter) Conf(timeout time.Duration) *resource.StateChangeConf {
+	return &resource.StateChangeConf{
+		Pending:                   []string{"IN_PROGRESS"},
+		Target:                    []string{"DONE"},
+		Refresh:                   w.RefreshFunc(),
+		Delay:                     10 * time.Second,
+		Timeout:                   timeout,
+		MinTimeout:                2 * time.Second,
+		ContinuousTargetOccurence: 3,
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"testing"
+)
+
+func Test_compareServiceVersions(t *testing.T) {
+	tests := []struct {
+		name   string
+		a      string
+		b      string
+		want   int
+		wantOk bool
+	}{
+		{"equal", "13", "13", 0, true},
+		{"major-upgrade", "12", "13", -1, true},
+		{"downgrade", "7.10", "7.9", 1, true},
+		{"minor-upgrade", "2.8", "3.0", -1, true},
+		{"different-length", "3.0", "3", 0, true},
+		{"not-numeric", "8", "latest", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotOk := compareServiceVersions(tt.a, tt.b)
+			if got != tt.want || gotOk != tt.wantOk {
+				t.Errorf("compareServiceVersions() = %v, %v, want %v, %v", got, gotOk, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
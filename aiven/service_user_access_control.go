@@ -0,0 +1,527 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lib/pq"
+)
+
+// serviceUserAccessControlPrivileges are the privileges that can be granted per service type
+var serviceUserAccessControlPrivileges = map[string][]string{
+	"pg":        {"ALL", "SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER"},
+	"mysql":     {"ALL", "SELECT", "INSERT", "UPDATE", "DELETE", "CREATE", "DROP", "ALTER", "INDEX", "REFERENCES", "TRIGGER", "CREATE VIEW", "SHOW VIEW", "EXECUTE"},
+	"cassandra": {"ALL", "SELECT", "MODIFY", "CREATE", "ALTER", "DROP", "AUTHORIZE"},
+}
+
+// serviceUserAccessControlAllPrivileges returns the privileges of all service types
+func serviceUserAccessControlAllPrivileges() []string {
+	var all []string
+	seen := map[string]bool{}
+	for _, t := range []string{"pg", "mysql", "cassandra"} {
+		for _, p := range serviceUserAccessControlPrivileges[t] {
+			if !seen[p] {
+				seen[p] = true
+				all = append(all, p)
+			}
+		}
+	}
+
+	return all
+}
+
+var aivenServiceUserAccessControlSchema = &schema.Schema{
+	Type:     schema.TypeList,
+	Optional: true,
+	MaxItems: 1,
+	Description: "PostgreSQL, MySQL and Cassandra specific field, grants privileges and roles to the user. The grants are applied " +
+		"with the admin user of the service over a connection to the service, which has to be reachable from where Terraform " +
+		"runs. Grants are applied when the user is created or the block changes and revoked when they are removed from the " +
+		"block or the user is deleted, grants changed outside of Terraform are not detected and the block is not read on import.",
+	Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"grant": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Privileges of the user on a database, keyspace or table.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"database": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+							Description:  "Name of the database, the keyspace for Cassandra.",
+						},
+						"schema": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "PostgreSQL specific field, name of the schema of the tables. The default is `public`.",
+						},
+						"table": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Description: "Name of the table. When not set the privileges are granted on all tables of the database, " +
+								"for PostgreSQL on the tables of the schema that exist when the grant is applied.",
+						},
+						"privileges": {
+							Type:     schema.TypeSet,
+							Required: true,
+							MinItems: 1,
+							Description: "Privileges granted on the tables. PostgreSQL supports `ALL`, `SELECT`, `INSERT`, " +
+								"`UPDATE`, `DELETE`, `TRUNCATE`, `REFERENCES` and `TRIGGER`, MySQL `ALL`, `SELECT`, `INSERT`, `UPDATE`, " +
+								"`DELETE`, `CREATE`, `DROP`, `ALTER`, `INDEX`, `REFERENCES`, `TRIGGER`, `CREATE VIEW`, `SHOW VIEW` and " +
+								"`EXECUTE` and Cassandra `ALL`, `SELECT`, `MODIFY`, `CREATE`, `ALTER`, `DROP` and `AUTHORIZE`.",
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(serviceUserAccessControlAllPrivileges(), false),
+							},
+						},
+					},
+				},
+			},
+			"roles": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Roles granted to the user, the roles have to exist in the service.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+			"read_only_databases": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Description: "Databases, keyspaces for Cassandra, the user can read all tables of. For PostgreSQL this grants " +
+					"`SELECT` on the tables and sequences of the `public` schema, including those created later by the admin user.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+		},
+	},
+}
+
+// serviceUserGrant is a grant of the access_control block, Privileges are sorted
+type serviceUserGrant struct {
+	Database   string
+	Schema     string
+	Table      string
+	Privileges []string
+}
+
+// key identifies a grant with its privileges
+func (g serviceUserGrant) key() string {
+	return strings.Join([]string{g.Database, g.Schema, g.Table, strings.Join(g.Privileges, ",")}, "\x00")
+}
+
+// serviceUserAccessControl is the expanded access_control block
+type serviceUserAccessControl struct {
+	Grants            []serviceUserGrant
+	Roles             []string
+	ReadOnlyDatabases []string
+}
+
+// expandServiceUserAccessControl expands the access_control block, an unset block has no grants
+func expandServiceUserAccessControl(v []interface{}) serviceUserAccessControl {
+	var ac serviceUserAccessControl
+	if len(v) == 0 || v[0] == nil {
+		return ac
+	}
+	block := v[0].(map[string]interface{})
+
+	if grants, ok := block["grant"].(*schema.Set); ok {
+		for _, g := range grants.List() {
+			grant := g.(map[string]interface{})
+			privileges := flattenToString(grant["privileges"].(*schema.Set).List())
+			sort.Strings(privileges)
+			ac.Grants = append(ac.Grants, serviceUserGrant{
+				Database:   grant["database"].(string),
+				Schema:     grant["schema"].(string),
+				Table:      grant["table"].(string),
+				Privileges: privileges,
+			})
+		}
+	}
+	if roles, ok := block["roles"].(*schema.Set); ok {
+		ac.Roles = flattenToString(roles.List())
+	}
+	if databases, ok := block["read_only_databases"].(*schema.Set); ok {
+		ac.ReadOnlyDatabases = flattenToString(databases.List())
+	}
+
+	sort.Slice(ac.Grants, func(i, j int) bool { return ac.Grants[i].key() < ac.Grants[j].key() })
+	sort.Strings(ac.Roles)
+	sort.Strings(ac.ReadOnlyDatabases)
+
+	return ac
+}
+
+// serviceUserAccessControlDialect builds the grant and revoke statements of a service type
+type serviceUserAccessControlDialect interface {
+	grant(username string, g serviceUserGrant, revoke bool) []serviceStatement
+	role(username, role string, revoke bool) []serviceStatement
+	readOnly(username, database string, revoke bool) []serviceStatement
+}
+
+// serviceUserAccessControlStatements returns the statements that change the grants of a user
+// from old to new, the removed grants are revoked before the added ones are granted
+func serviceUserAccessControlStatements(serviceType, username string, old, new serviceUserAccessControl) ([]serviceStatement, error) {
+	if err := validateServiceUserAccessControl(serviceType, new); err != nil {
+		return nil, err
+	}
+
+	if serviceType == "pg" {
+		old, new = normalizePGServiceUserAccessControl(old), normalizePGServiceUserAccessControl(new)
+	}
+
+	var dialect serviceUserAccessControlDialect
+	switch serviceType {
+	case "pg":
+		dialect = pgServiceUserAccessControl{kept: new}
+	case "mysql":
+		dialect = mysqlServiceUserAccessControl{}
+	case "cassandra":
+		dialect = cassandraServiceUserAccessControl{}
+	default:
+		return nil, fmt.Errorf("access_control is only supported for PostgreSQL, MySQL and Cassandra services, not %q", serviceType)
+	}
+
+	var statements []serviceStatement
+	for _, revoke := range []bool{true, false} {
+		from, to := new, old
+		if revoke {
+			from, to = old, new
+		}
+
+		for _, g := range from.Grants {
+			if !serviceUserAccessControlHasGrant(to.Grants, g) {
+				statements = append(statements, dialect.grant(username, g, revoke)...)
+			}
+		}
+		for _, r := range from.Roles {
+			if !serviceUserAccessControlHasString(to.Roles, r) {
+				statements = append(statements, dialect.role(username, r, revoke)...)
+			}
+		}
+		for _, db := range from.ReadOnlyDatabases {
+			if !serviceUserAccessControlHasString(to.ReadOnlyDatabases, db) {
+				statements = append(statements, dialect.readOnly(username, db, revoke)...)
+			}
+		}
+	}
+
+	// grants sharing a schema or database repeat their usage statements
+	var unique []serviceStatement
+	seen := map[serviceStatement]bool{}
+	for _, s := range statements {
+		if !seen[s] {
+			seen[s] = true
+			unique = append(unique, s)
+		}
+	}
+
+	return unique, nil
+}
+
+// validateServiceUserAccessControl checks the grants against the service type
+func validateServiceUserAccessControl(serviceType string, ac serviceUserAccessControl) error {
+	privileges, ok := serviceUserAccessControlPrivileges[serviceType]
+	if !ok {
+		return fmt.Errorf("access_control is only supported for PostgreSQL, MySQL and Cassandra services, not %q", serviceType)
+	}
+
+	for _, g := range ac.Grants {
+		if g.Schema != "" && serviceType != "pg" {
+			return fmt.Errorf("grant on %q: schema is only supported for PostgreSQL", g.Database)
+		}
+		for _, p := range g.Privileges {
+			if !serviceUserAccessControlHasString(privileges, p) {
+				return fmt.Errorf("grant on %q: privilege %q is not supported for %s, expected one of %s",
+					g.Database, p, serviceType, strings.Join(privileges, ", "))
+			}
+		}
+	}
+
+	return nil
+}
+
+func serviceUserAccessControlHasGrant(grants []serviceUserGrant, g serviceUserGrant) bool {
+	for _, o := range grants {
+		if o.key() == g.key() {
+			return true
+		}
+	}
+	return false
+}
+
+func serviceUserAccessControlHasString(values []string, v string) bool {
+	for _, o := range values {
+		if o == v {
+			return true
+		}
+	}
+	return false
+}
+
+// serviceUserAccessControlPrivilegeList joins privileges, ALL is written as allKeyword
+func serviceUserAccessControlPrivilegeList(privileges []string, allKeyword string) string {
+	var list []string
+	for _, p := range privileges {
+		if p == "ALL" {
+			p = allKeyword
+		}
+		list = append(list, p)
+	}
+	return strings.Join(list, ", ")
+}
+
+// normalizePGServiceUserAccessControl sets the default schema of PostgreSQL grants
+func normalizePGServiceUserAccessControl(ac serviceUserAccessControl) serviceUserAccessControl {
+	grants := make([]serviceUserGrant, len(ac.Grants))
+	for i, g := range ac.Grants {
+		if g.Schema == "" {
+			g.Schema = "public"
+		}
+		grants[i] = g
+	}
+	ac.Grants = grants
+
+	return ac
+}
+
+// pgServiceUserAccessControl builds PostgreSQL statements, the usage of schemas and the
+// connect privilege of databases still used by the kept grants are not revoked
+type pgServiceUserAccessControl struct {
+	kept serviceUserAccessControl
+}
+
+func (p pgServiceUserAccessControl) usesSchema(database, schema string) bool {
+	for _, g := range p.kept.Grants {
+		if g.Database == database && g.Schema == schema {
+			return true
+		}
+	}
+	return schema == "public" && serviceUserAccessControlHasString(p.kept.ReadOnlyDatabases, database)
+}
+
+func (p pgServiceUserAccessControl) usesDatabase(database string) bool {
+	for _, g := range p.kept.Grants {
+		if g.Database == database {
+			return true
+		}
+	}
+	return serviceUserAccessControlHasString(p.kept.ReadOnlyDatabases, database)
+}
+
+// usage returns the statements of the connect and schema usage privileges that grants need
+func (p pgServiceUserAccessControl) usage(username, database, schema string, revoke bool) []serviceStatement {
+	user := pq.QuoteIdentifier(username)
+	if !revoke {
+		return []serviceStatement{
+			{database, fmt.Sprintf("GRANT CONNECT ON DATABASE %s TO %s", pq.QuoteIdentifier(database), user)},
+			{database, fmt.Sprintf("GRANT USAGE ON SCHEMA %s TO %s", pq.QuoteIdentifier(schema), user)},
+		}
+	}
+
+	var statements []serviceStatement
+	if !p.usesSchema(database, schema) {
+		statements = append(statements, serviceStatement{database,
+			fmt.Sprintf("REVOKE USAGE ON SCHEMA %s FROM %s", pq.QuoteIdentifier(schema), user)})
+	}
+	if !p.usesDatabase(database) {
+		statements = append(statements, serviceStatement{database,
+			fmt.Sprintf("REVOKE CONNECT ON DATABASE %s FROM %s", pq.QuoteIdentifier(database), user)})
+	}
+	return statements
+}
+
+func (p pgServiceUserAccessControl) grant(username string, g serviceUserGrant, revoke bool) []serviceStatement {
+	on := "ALL TABLES IN SCHEMA " + pq.QuoteIdentifier(g.Schema)
+	if g.Table != "" {
+		on = "TABLE " + pq.QuoteIdentifier(g.Schema) + "." + pq.QuoteIdentifier(g.Table)
+	}
+	privileges := serviceUserAccessControlPrivilegeList(g.Privileges, "ALL PRIVILEGES")
+	user := pq.QuoteIdentifier(username)
+
+	if revoke {
+		return append([]serviceStatement{
+			{g.Database, fmt.Sprintf("REVOKE %s ON %s FROM %s", privileges, on, user)},
+		}, p.usage(username, g.Database, g.Schema, true)...)
+	}
+
+	return append(p.usage(username, g.Database, g.Schema, false),
+		serviceStatement{g.Database, fmt.Sprintf("GRANT %s ON %s TO %s", privileges, on, user)})
+}
+
+func (pgServiceUserAccessControl) role(username, role string, revoke bool) []serviceStatement {
+	if revoke {
+		return []serviceStatement{{"", fmt.Sprintf("REVOKE %s FROM %s", pq.QuoteIdentifier(role), pq.QuoteIdentifier(username))}}
+	}
+	return []serviceStatement{{"", fmt.Sprintf("GRANT %s TO %s", pq.QuoteIdentifier(role), pq.QuoteIdentifier(username))}}
+}
+
+func (p pgServiceUserAccessControl) readOnly(username, database string, revoke bool) []serviceStatement {
+	user := pq.QuoteIdentifier(username)
+	if revoke {
+		return append([]serviceStatement{
+			{database, "REVOKE SELECT ON ALL TABLES IN SCHEMA public FROM " + user},
+			{database, "REVOKE SELECT ON ALL SEQUENCES IN SCHEMA public FROM " + user},
+			{database, "ALTER DEFAULT PRIVILEGES IN SCHEMA public REVOKE SELECT ON TABLES FROM " + user},
+			{database, "ALTER DEFAULT PRIVILEGES IN SCHEMA public REVOKE SELECT ON SEQUENCES FROM " + user},
+		}, p.usage(username, database, "public", true)...)
+	}
+
+	return append(p.usage(username, database, "public", false),
+		serviceStatement{database, "GRANT SELECT ON ALL TABLES IN SCHEMA public TO " + user},
+		serviceStatement{database, "GRANT SELECT ON ALL SEQUENCES IN SCHEMA public TO " + user},
+		serviceStatement{database, "ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT SELECT ON TABLES TO " + user},
+		serviceStatement{database, "ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT SELECT ON SEQUENCES TO " + user},
+	)
+}
+
+// mysqlServiceUserAccessControl builds MySQL statements, Aiven users and roles are defined
+// for any host
+type mysqlServiceUserAccessControl struct{}
+
+func mysqlQuoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+func mysqlQuoteAccount(name string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `''`).Replace(name) + "'@'%'"
+}
+
+func mysqlGrantStatement(privileges, on, username string, revoke bool) serviceStatement {
+	if revoke {
+		return serviceStatement{"", fmt.Sprintf("REVOKE %s ON %s FROM %s", privileges, on, mysqlQuoteAccount(username))}
+	}
+	return serviceStatement{"", fmt.Sprintf("GRANT %s ON %s TO %s", privileges, on, mysqlQuoteAccount(username))}
+}
+
+func (mysqlServiceUserAccessControl) grant(username string, g serviceUserGrant, revoke bool) []serviceStatement {
+	on := mysqlQuoteIdentifier(g.Database) + ".*"
+	if g.Table != "" {
+		on = mysqlQuoteIdentifier(g.Database) + "." + mysqlQuoteIdentifier(g.Table)
+	}
+
+	privileges := serviceUserAccessControlPrivilegeList(g.Privileges, "ALL PRIVILEGES")
+	return []serviceStatement{mysqlGrantStatement(privileges, on, username, revoke)}
+}
+
+func (mysqlServiceUserAccessControl) role(username, role string, revoke bool) []serviceStatement {
+	if revoke {
+		return []serviceStatement{{"", fmt.Sprintf("REVOKE %s FROM %s", mysqlQuoteAccount(role), mysqlQuoteAccount(username))}}
+	}
+	return []serviceStatement{{"", fmt.Sprintf("GRANT %s TO %s", mysqlQuoteAccount(role), mysqlQuoteAccount(username))}}
+}
+
+func (mysqlServiceUserAccessControl) readOnly(username, database string, revoke bool) []serviceStatement {
+	return []serviceStatement{mysqlGrantStatement("SELECT, SHOW VIEW", mysqlQuoteIdentifier(database)+".*", username, revoke)}
+}
+
+// cassandraServiceUserAccessControl builds Cassandra statements, a statement grants a single
+// permission
+type cassandraServiceUserAccessControl struct{}
+
+func cassandraQuoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func cassandraGrantStatement(permission, on, username string, revoke bool) serviceStatement {
+	if permission == "ALL" {
+		permission = "ALL PERMISSIONS"
+	}
+	if revoke {
+		return serviceStatement{"", fmt.Sprintf("REVOKE %s ON %s FROM %s", permission, on, cassandraQuoteIdentifier(username))}
+	}
+	return serviceStatement{"", fmt.Sprintf("GRANT %s ON %s TO %s", permission, on, cassandraQuoteIdentifier(username))}
+}
+
+func (cassandraServiceUserAccessControl) grant(username string, g serviceUserGrant, revoke bool) []serviceStatement {
+	on := "KEYSPACE " + cassandraQuoteIdentifier(g.Database)
+	if g.Table != "" {
+		on = "TABLE " + cassandraQuoteIdentifier(g.Database) + "." + cassandraQuoteIdentifier(g.Table)
+	}
+
+	var statements []serviceStatement
+	for _, p := range g.Privileges {
+		statements = append(statements, cassandraGrantStatement(p, on, username, revoke))
+	}
+	return statements
+}
+
+func (cassandraServiceUserAccessControl) role(username, role string, revoke bool) []serviceStatement {
+	if revoke {
+		return []serviceStatement{{"", fmt.Sprintf("REVOKE %s FROM %s", cassandraQuoteIdentifier(role), cassandraQuoteIdentifier(username))}}
+	}
+	return []serviceStatement{{"", fmt.Sprintf("GRANT %s TO %s", cassandraQuoteIdentifier(role), cassandraQuoteIdentifier(username))}}
+}
+
+func (cassandraServiceUserAccessControl) readOnly(username, database string, revoke bool) []serviceStatement {
+	return []serviceStatement{cassandraGrantStatement("SELECT", "KEYSPACE "+cassandraQuoteIdentifier(database), username, revoke)}
+}
+
+// resourceServiceUserApplyAccessControl changes the grants of a service user from old to new
+// access_control blocks
+func resourceServiceUserApplyAccessControl(ctx context.Context, d *schema.ResourceData, m interface{}, old, new interface{}) error {
+	oldAC := expandServiceUserAccessControl(old.([]interface{}))
+	newAC := expandServiceUserAccessControl(new.([]interface{}))
+	if len(oldAC.Grants)+len(oldAC.Roles)+len(oldAC.ReadOnlyDatabases)+
+		len(newAC.Grants)+len(newAC.Roles)+len(newAC.ReadOnlyDatabases) == 0 {
+		return nil
+	}
+
+	projectName, serviceName, username := splitResourceID3(d.Id())
+	conn, err := getServiceConnection(m.(*providerMeta).client, projectName, serviceName)
+	if err != nil {
+		return err
+	}
+
+	statements, err := serviceUserAccessControlStatements(conn.ServiceType, username, oldAC, newAC)
+	if err != nil {
+		return err
+	}
+
+	if err := execServiceStatements(ctx, conn, statements); err != nil {
+		return fmt.Errorf("cannot change access control of service user %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// resourceServiceUserRevokeAccessControl revokes the grants of the access_control block before
+// the user is deleted, PostgreSQL does not drop users that have privileges. The statements are
+// run one by one so that a grant on a database that was already deleted does not keep the
+// others from being revoked.
+func resourceServiceUserRevokeAccessControl(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	ac := expandServiceUserAccessControl(d.Get("access_control").([]interface{}))
+	if len(ac.Grants)+len(ac.Roles)+len(ac.ReadOnlyDatabases) == 0 {
+		return nil
+	}
+
+	projectName, serviceName, username := splitResourceID3(d.Id())
+	conn, err := getServiceConnection(m.(*providerMeta).client, projectName, serviceName)
+	if err != nil {
+		return err
+	}
+
+	statements, err := serviceUserAccessControlStatements(conn.ServiceType, username, ac, serviceUserAccessControl{})
+	if err != nil {
+		return err
+	}
+
+	for _, s := range statements {
+		if err := execServiceStatements(ctx, conn, []serviceStatement{s}); err != nil {
+			log.Printf("[WARN] cannot revoke access of service user %s: %s", d.Id(), err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func Test_expandServiceUserAccessControl(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, aivenServiceUserSchema, map[string]interface{}{
+		"project":      "test-project",
+		"service_name": "test-service",
+		"username":     "test-user",
+		"access_control": []interface{}{map[string]interface{}{
+			"grant": []interface{}{
+				map[string]interface{}{"database": "db2", "privileges": []interface{}{"SELECT"}},
+				map[string]interface{}{"database": "db1", "schema": "app", "table": "t1", "privileges": []interface{}{"UPDATE", "INSERT"}},
+			},
+			"roles":               []interface{}{"writer", "reader"},
+			"read_only_databases": []interface{}{"db3"},
+		}},
+	})
+
+	want := serviceUserAccessControl{
+		Grants: []serviceUserGrant{
+			{Database: "db1", Schema: "app", Table: "t1", Privileges: []string{"INSERT", "UPDATE"}},
+			{Database: "db2", Privileges: []string{"SELECT"}},
+		},
+		Roles:             []string{"reader", "writer"},
+		ReadOnlyDatabases: []string{"db3"},
+	}
+	if got := expandServiceUserAccessControl(d.Get("access_control").([]interface{})); !reflect.DeepEqual(got, want) {
+		t.Errorf("expandServiceUserAccessControl() = %+v, want %+v", got, want)
+	}
+
+	if got := expandServiceUserAccessControl(nil); !reflect.DeepEqual(got, serviceUserAccessControl{}) {
+		t.Errorf("expandServiceUserAccessControl(nil) = %+v, want no grants", got)
+	}
+}
+
+func Test_serviceUserAccessControlStatements(t *testing.T) {
+	tablesGrant := serviceUserGrant{Database: "db1", Privileges: []string{"INSERT", "SELECT"}}
+	tableGrant := serviceUserGrant{Database: "db1", Schema: "app", Table: "t1", Privileges: []string{"ALL"}}
+
+	tests := []struct {
+		name        string
+		serviceType string
+		old         serviceUserAccessControl
+		new         serviceUserAccessControl
+		want        []serviceStatement
+		wantErr     bool
+	}{
+		{
+			"pg-grant",
+			"pg",
+			serviceUserAccessControl{},
+			serviceUserAccessControl{Grants: []serviceUserGrant{tablesGrant, tableGrant}, Roles: []string{"reader"}},
+			[]serviceStatement{
+				{"db1", `GRANT CONNECT ON DATABASE "db1" TO "u1"`},
+				{"db1", `GRANT USAGE ON SCHEMA "public" TO "u1"`},
+				{"db1", `GRANT INSERT, SELECT ON ALL TABLES IN SCHEMA "public" TO "u1"`},
+				{"db1", `GRANT USAGE ON SCHEMA "app" TO "u1"`},
+				{"db1", `GRANT ALL PRIVILEGES ON TABLE "app"."t1" TO "u1"`},
+				{"", `GRANT "reader" TO "u1"`},
+			},
+			false,
+		},
+		{
+			"pg-revoke-keeps-usage-of-kept-grants",
+			"pg",
+			serviceUserAccessControl{Grants: []serviceUserGrant{tablesGrant, tableGrant}},
+			serviceUserAccessControl{Grants: []serviceUserGrant{tableGrant}},
+			[]serviceStatement{
+				{"db1", `REVOKE INSERT, SELECT ON ALL TABLES IN SCHEMA "public" FROM "u1"`},
+				{"db1", `REVOKE USAGE ON SCHEMA "public" FROM "u1"`},
+			},
+			false,
+		},
+		{
+			"pg-changed-privileges",
+			"pg",
+			serviceUserAccessControl{Grants: []serviceUserGrant{tablesGrant}},
+			serviceUserAccessControl{Grants: []serviceUserGrant{{Database: "db1", Schema: "public", Privileges: []string{"SELECT"}}}},
+			[]serviceStatement{
+				{"db1", `REVOKE INSERT, SELECT ON ALL TABLES IN SCHEMA "public" FROM "u1"`},
+				{"db1", `GRANT CONNECT ON DATABASE "db1" TO "u1"`},
+				{"db1", `GRANT USAGE ON SCHEMA "public" TO "u1"`},
+				{"db1", `GRANT SELECT ON ALL TABLES IN SCHEMA "public" TO "u1"`},
+			},
+			false,
+		},
+		{
+			"pg-read-only-revoke-all",
+			"pg",
+			serviceUserAccessControl{ReadOnlyDatabases: []string{"db2"}},
+			serviceUserAccessControl{},
+			[]serviceStatement{
+				{"db2", `REVOKE SELECT ON ALL TABLES IN SCHEMA public FROM "u1"`},
+				{"db2", `REVOKE SELECT ON ALL SEQUENCES IN SCHEMA public FROM "u1"`},
+				{"db2", `ALTER DEFAULT PRIVILEGES IN SCHEMA public REVOKE SELECT ON TABLES FROM "u1"`},
+				{"db2", `ALTER DEFAULT PRIVILEGES IN SCHEMA public REVOKE SELECT ON SEQUENCES FROM "u1"`},
+				{"db2", `REVOKE USAGE ON SCHEMA "public" FROM "u1"`},
+				{"db2", `REVOKE CONNECT ON DATABASE "db2" FROM "u1"`},
+			},
+			false,
+		},
+		{
+			"pg-unchanged",
+			"pg",
+			serviceUserAccessControl{Grants: []serviceUserGrant{tablesGrant}},
+			serviceUserAccessControl{Grants: []serviceUserGrant{{Database: "db1", Schema: "public", Privileges: []string{"INSERT", "SELECT"}}}},
+			nil,
+			false,
+		},
+		{
+			"mysql",
+			"mysql",
+			serviceUserAccessControl{Roles: []string{"old-role"}},
+			serviceUserAccessControl{
+				Grants:            []serviceUserGrant{{Database: "db`1", Table: "t1", Privileges: []string{"ALL"}}},
+				ReadOnlyDatabases: []string{"db2"},
+			},
+			[]serviceStatement{
+				{"", `REVOKE 'old-role'@'%' FROM 'u1'@'%'`},
+				{"", "GRANT ALL PRIVILEGES ON `db``1`.`t1` TO 'u1'@'%'"},
+				{"", "GRANT SELECT, SHOW VIEW ON `db2`.* TO 'u1'@'%'"},
+			},
+			false,
+		},
+		{
+			"cassandra",
+			"cassandra",
+			serviceUserAccessControl{},
+			serviceUserAccessControl{
+				Grants:            []serviceUserGrant{{Database: "ks1", Privileges: []string{"ALL", "MODIFY"}}},
+				Roles:             []string{"reader"},
+				ReadOnlyDatabases: []string{"ks2"},
+			},
+			[]serviceStatement{
+				{"", `GRANT ALL PERMISSIONS ON KEYSPACE "ks1" TO "u1"`},
+				{"", `GRANT MODIFY ON KEYSPACE "ks1" TO "u1"`},
+				{"", `GRANT "reader" TO "u1"`},
+				{"", `GRANT SELECT ON KEYSPACE "ks2" TO "u1"`},
+			},
+			false,
+		},
+		{
+			"privilege-of-another-service-type",
+			"cassandra",
+			serviceUserAccessControl{},
+			serviceUserAccessControl{Grants: []serviceUserGrant{{Database: "ks1", Privileges: []string{"INSERT"}}}},
+			nil,
+			true,
+		},
+		{
+			"schema-not-supported",
+			"mysql",
+			serviceUserAccessControl{},
+			serviceUserAccessControl{Grants: []serviceUserGrant{{Database: "db1", Schema: "app", Privileges: []string{"SELECT"}}}},
+			nil,
+			true,
+		},
+		{
+			"unsupported-service-type",
+			"kafka",
+			serviceUserAccessControl{},
+			serviceUserAccessControl{Roles: []string{"reader"}},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := serviceUserAccessControlStatements(tt.serviceType, "u1", tt.old, tt.new)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("serviceUserAccessControlStatements() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("serviceUserAccessControlStatements() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,11 +5,14 @@ package aiven
 import (
 	"fmt"
 	"math"
+	"net"
 	"strconv"
 	"strings"
 
 	"github.com/aiven/terraform-provider-aiven/aiven/templates"
+	"github.com/aiven/terraform-provider-aiven/pkg/ipfilter"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // GenerateTerraformUserConfigSchema creates Terraform schema definition for user config based
@@ -27,9 +30,64 @@ func GenerateTerraformUserConfigSchema(data map[string]interface{}) map[string]*
 		terraformSchema[encodeKeyName(name)] = generateTerraformUserConfigSchema(name, definition)
 	}
 
+	// the API accepts IP filter entries with descriptions in `ip_filter` as well
+	if _, ok := properties["ip_filter"]; ok {
+		terraformSchema["ip_filter_object"] = ipFilterObjectSchema()
+	}
+
 	return terraformSchema
 }
 
+// ipFilterObjectSchema is the schema of the IP filter entries with descriptions, they are sent
+// to the API in `ip_filter` together with the plain networks
+func ipFilterObjectSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "IP filter entries with descriptions, combined with `ip_filter`",
+		Optional:    true,
+		Type:        schema.TypeSet,
+		MaxItems:    1024,
+		Set:         ipFilterObjectHash,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"network": {
+					Type:             schema.TypeString,
+					Required:         true,
+					ValidateFunc:     validateIPFilterNetwork,
+					DiffSuppressFunc: ipFilterNetworkDiffSuppressFunc,
+					Description:      "CIDR address block, e.g. '10.20.0.0/16'",
+				},
+				"description": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringLenBetween(0, 1024),
+					Description:  "Description of the IP filter entry",
+				},
+			},
+		},
+	}
+}
+
+// ipFilterObjectHash hashes an IP filter entry with a description by its canonical network, so
+// that the notation of the network does not change the entry
+func ipFilterObjectHash(v interface{}) int {
+	m := v.(map[string]interface{})
+	d, _ := m["description"].(string)
+
+	return schema.HashString(ipfilter.Canonical(ipfilter.Network(m)) + "/" + d)
+}
+
+// validateIPFilterNetwork is a ValidateFunc that ensures a string is an IP address or a CIDR block
+func validateIPFilterNetwork(v interface{}, k string) (ws []string, errors []error) {
+	s := v.(string)
+	if net.ParseIP(s) == nil {
+		if _, _, err := net.ParseCIDR(s); err != nil {
+			errors = append(errors, fmt.Errorf("%q: %q is not an IP address or a CIDR block", k, s))
+		}
+	}
+
+	return
+}
+
 func generateTerraformUserConfigSchema(key string, definition map[string]interface{}) *schema.Schema {
 	valueType := getAivenSchemaType(definition["type"])
 	sensitive := false
@@ -205,7 +263,12 @@ func convertAPIUserConfigToTerraformCompatibleFormat(
 			case int:
 				terraformConfig[key] = strconv.Itoa(apiValue.(int))
 			case []interface{}:
-				if hasNestedUserConfigurationOptionItems(apiValue, schemaDefinition) {
+				if key == "ip_filter" {
+					// entries with descriptions are objects, plain networks are strings
+					networks, objects := ipfilter.Split(value)
+					terraformConfig[key] = networks
+					terraformConfig["ip_filter_object"] = objects
+				} else if hasNestedUserConfigurationOptionItems(apiValue, schemaDefinition) {
 					var list []interface{}
 					for _, v := range apiValue.([]interface{}) {
 						res := convertAPIUserConfigToTerraformCompatibleFormat(
@@ -282,6 +345,10 @@ func convertTerraformUserConfigToAPICompatibleFormat(
 	apiConfig := make(map[string]interface{})
 
 	for key, value := range userConfig {
+		if key == "ip_filter_object" {
+			continue
+		}
+
 		key = decodeKeyName(key)
 		definitionRaw, ok := configSchema[key]
 		if !ok {
@@ -302,9 +369,28 @@ func convertTerraformUserConfigToAPICompatibleFormat(
 		}
 	}
 
+	if objects, ok := userConfig["ip_filter_object"]; ok {
+		networks, _ := apiConfig["ip_filter"].([]interface{})
+		if filter := ipfilter.Merge(networks, ipFilterObjectList(objects)); len(filter) > 0 || networks != nil {
+			apiConfig["ip_filter"] = filter
+		}
+	}
+
 	return apiConfig
 }
 
+// ipFilterObjectList returns the IP filter entries with descriptions of a user configuration
+func ipFilterObjectList(v interface{}) []interface{} {
+	switch l := v.(type) {
+	case *schema.Set:
+		return l.List()
+	case []interface{}:
+		return l
+	default:
+		return nil
+	}
+}
+
 func convertTerraformUserConfigValueToAPICompatibleFormat(
 	serviceType string,
 	newResource bool,
@@ -235,6 +235,35 @@ func Test_convertTerraformUserConfigToAPICompatibleFormat(t *testing.T) {
 				"schema_registry":      false,
 			},
 		},
+		{
+			"ip_filter_object",
+			args{
+				serviceType: "kafka",
+				newResource: false,
+				userConfig: map[string]interface{}{
+					"ip_filter": []interface{}{
+						"10.0.0.1",
+						"192.168.0.0/16",
+					},
+					"ip_filter_object": []interface{}{
+						map[string]interface{}{
+							"network":     "10.0.0.1/32",
+							"description": "office",
+						},
+					},
+				},
+				configSchema: entrySchemaProps,
+			},
+			map[string]interface{}{
+				"ip_filter": []interface{}{
+					map[string]interface{}{
+						"network":     "10.0.0.1/32",
+						"description": "office",
+					},
+					"192.168.0.0/16",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
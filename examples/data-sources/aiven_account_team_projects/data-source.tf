@@ -0,0 +1,8 @@
+data "aiven_account_team_projects" "grants" {
+    account_id = aiven_account.<ACCOUNT_RESOURCE>.account_id
+    project_name = aiven_project.myproject.project
+}
+
+output "project_admin_teams" {
+    value = [for g in data.aiven_account_team_projects.grants.project_grants : g.team_name if g.team_type == "admin"]
+}
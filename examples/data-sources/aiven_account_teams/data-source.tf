@@ -0,0 +1,7 @@
+data "aiven_account_teams" "teams" {
+    account_id = aiven_account.<ACCOUNT_RESOURCE>.account_id
+}
+
+output "team_members" {
+    value = { for t in data.aiven_account_teams.teams.teams : t.name => t.member_emails }
+}
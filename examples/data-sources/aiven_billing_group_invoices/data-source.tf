@@ -0,0 +1,8 @@
+data "aiven_billing_group_invoices" "paid" {
+    billing_group_id = aiven_billing_group.<BILLING_GROUP_RESOURCE>.id
+    state = "paid"
+}
+
+output "paid_totals" {
+    value = { for i in data.aiven_billing_group_invoices.paid.invoices : i.invoice_number => "${i.total_inc_vat} ${i.currency}" }
+}
@@ -0,0 +1,5 @@
+data "aiven_project_events" "recent" {
+    project = aiven_project.myproject.project
+    start_time = "2021-11-01T00:00:00Z"
+    actor = "john.doe@example.com"
+}
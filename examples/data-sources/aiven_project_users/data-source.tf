@@ -0,0 +1,7 @@
+data "aiven_project_users" "users" {
+    project = aiven_project.myproject.project
+}
+
+output "project_admins" {
+    value = [for u in data.aiven_project_users.users.users : u.email if u.member_type == "admin"]
+}
@@ -0,0 +1,4 @@
+data "aiven_service_ip_addresses" "pg" {
+  project      = aiven_pg.pg.project
+  service_name = aiven_pg.pg.service_name
+}
@@ -0,0 +1,5 @@
+data "aiven_service_plans" "pg" {
+  project      = aiven_project.pr1.project
+  service_type = "pg"
+  cloud_name   = "google-europe-west1"
+}
@@ -7,3 +7,19 @@ resource "aiven_account_authentication" "foo" {
     saml_entity_id = "https://example.com/00000"
     saml_idp_url = "https://example.com/sso/saml"
 }
+
+resource "aiven_account_authentication" "sso" {
+    account_id = aiven_account.<ACCOUNT_RESOURCE>.account_id
+    name = "sso"
+    type = "saml"
+    enabled = true
+    saml_idp_metadata_xml = file("idp-metadata.xml")
+    saml_signature_algorithm = "rsa-sha256"
+    saml_digest_algorithm = "sha256"
+    auto_join_team_id = aiven_account_team.<ACCOUNT_TEAM_RESOURCE>.team_id
+
+    saml_field_mapping {
+        email = "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress"
+        real_name = "http://schemas.microsoft.com/identity/claims/displayname"
+    }
+}
@@ -3,3 +3,17 @@ resource "aiven_database" "mydatabase" {
     service_name = aiven_service.myservice.service_name
     database_name = "<DATABASE_NAME>"
 }
+
+resource "aiven_database" "mypgdatabase" {
+    project = aiven_project.myproject.project
+    service_name = aiven_pg.mypg.service_name
+    database_name = "<DATABASE_NAME>"
+    owner = aiven_service_user.myowner.username
+    extensions = ["pg_stat_statements", "postgis"]
+
+    default_privileges {
+        role = aiven_service_user.myreader.username
+        object_type = "TABLES"
+        privileges = ["SELECT"]
+    }
+}
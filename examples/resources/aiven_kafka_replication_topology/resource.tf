@@ -0,0 +1,29 @@
+resource "aiven_kafka_replication_topology" "mesh" {
+  project      = aiven_project.kafka-mm-project1.project
+  service_name = aiven_kafka_mirrormaker.mm.service_name
+
+  cluster {
+    alias        = "us-east"
+    service_name = aiven_kafka.kafka-ue1.service_name
+  }
+
+  cluster {
+    alias        = "us-west"
+    service_name = aiven_kafka.kafka-uw2.service_name
+  }
+
+  cluster {
+    alias        = "eu-west"
+    service_name = aiven_kafka.kafka-ew1.service_name
+  }
+
+  topics = [
+    ".*",
+  ]
+
+  topics_blacklist = [
+    ".*[\\-\\.]internal",
+    ".*\\.replica",
+    "__.*"
+  ]
+}
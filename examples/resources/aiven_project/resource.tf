@@ -2,4 +2,21 @@ resource "aiven_project" "myproject" {
     project = "<PROJECT_NAME>"
     card_id = "<FULL_CARD_ID/LAST4_DIGITS>"
     account_id = aiven_account_team.<ACCOUNT_RESOURCE>.account_id
+
+    tag {
+        key = "cost_center"
+        value = "1234"
+    }
+}
+
+resource "aiven_project" "staging" {
+    project = "<PROJECT_NAME>-staging"
+    account_id = aiven_project.myproject.account_id
+
+    clone {
+        source_project = aiven_project.myproject.project
+        vpcs = true
+        integration_endpoints = true
+        team_grants = true
+    }
 }
@@ -0,0 +1,4 @@
+resource "aiven_project_credit" "promotion" {
+    project = aiven_project.myproject.project
+    code = "<CREDIT_CODE>"
+}
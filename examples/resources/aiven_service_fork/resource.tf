@@ -0,0 +1,7 @@
+resource "aiven_service_fork" "fork" {
+  project              = aiven_project.myproject.project
+  source_service_name  = aiven_pg.pg.service_name
+  service_name         = "my-pg-fork"
+  plan                 = "startup-4"
+  recovery_target_time = "2021-11-20T10:00:00Z"
+}
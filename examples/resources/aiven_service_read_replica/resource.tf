@@ -0,0 +1,10 @@
+resource "aiven_service_read_replica" "replica" {
+  project             = aiven_project.myproject.project
+  source_service_name = aiven_pg.pg.service_name
+  service_name        = "my-pg-replica"
+  plan                = "startup-4"
+  cloud_name          = "google-europe-west1"
+
+  # removes the replication integration, turning the replica into a standalone service
+  promote = false
+}
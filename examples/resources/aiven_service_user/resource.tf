@@ -3,3 +3,19 @@ resource "aiven_service_user" "myserviceuser" {
   service_name = aiven_service.myservice.service_name
   username = "<USERNAME>"
 }
+
+resource "aiven_service_user" "reader" {
+  project = aiven_project.myproject.project
+  service_name = aiven_pg.mypg.service_name
+  username = "<USERNAME>"
+
+  access_control {
+    grant {
+      database = "defaultdb"
+      schema = "app"
+      privileges = ["INSERT", "UPDATE"]
+    }
+    roles = ["<ROLE>"]
+    read_only_databases = ["defaultdb"]
+  }
+}
@@ -0,0 +1,4 @@
+resource "aiven_static_ip" "ip" {
+  project    = aiven_project.myproject.project
+  cloud_name = "google-europe-west1"
+}
@@ -0,0 +1,5 @@
+resource "aiven_static_ip_association" "ip" {
+  project              = aiven_project.myproject.project
+  static_ip_address_id = aiven_static_ip.ip.static_ip_address_id
+  service_name         = aiven_pg.pg.service_name
+}
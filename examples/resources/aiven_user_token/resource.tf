@@ -0,0 +1,6 @@
+resource "aiven_user_token" "ci" {
+  description = "CI pipeline"
+  max_age_seconds = 3600
+  extend_when_used = false
+  scopes = ["projects:read", "services"]
+}
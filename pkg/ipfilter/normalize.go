@@ -2,98 +2,216 @@
 // Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
 package ipfilter
 
-// Normalize compares a list of IP filters set in TF and a sorted version coming
-// from Aiven and takes sort IP filters such that all matching entries will be in
-// the same order as defined in the TF manifest.
-func Normalize(tfUserConfig interface{}, userConfig []map[string]interface{}) []map[string]interface{} {
-	tfInt, ok := tfUserConfig.([]interface{})
-	if !ok || len(tfInt) == 0 {
-		return userConfig
-	}
+import (
+	"net"
+	"strings"
+)
 
-	if len(userConfig) == 0 {
-		return userConfig
+// DefaultNetwork is the IP filter of a service that has no IP filter configured
+const DefaultNetwork = "0.0.0.0/0"
+
+// lister is implemented by *schema.Set, the package does not depend on the Terraform SDK
+type lister interface {
+	List() []interface{}
+}
+
+// Canonical returns the canonical CIDR notation of an IP filter network so that equal networks
+// compare equal, e.g. `10.0.0.1` and `10.0.0.1/32`. Values that are not IP addresses or CIDR
+// blocks are returned as they are.
+func Canonical(network string) string {
+	s := strings.TrimSpace(network)
+
+	if _, n, err := net.ParseCIDR(s); err == nil {
+		return n.String()
 	}
 
-	if _, ok := userConfig[0]["ip_filter"]; !ok {
-		return userConfig
+	if ip := net.ParseIP(s); ip != nil {
+		if ip4 := ip.To4(); ip4 != nil {
+			return ip4.String() + "/32"
+		}
+		return ip.String() + "/128"
 	}
 
-	if _, ok := tfInt[0].(map[string]interface{})["ip_filter"]; !ok {
-		return userConfig
+	return s
+}
+
+// Network returns the network of an IP filter entry given either as a string or as an object
+// with `network` and `description` fields
+func Network(entry interface{}) string {
+	switch e := entry.(type) {
+	case string:
+		return e
+	case map[string]interface{}:
+		n, _ := e["network"].(string)
+		return n
+	default:
+		return ""
 	}
+}
 
-	api := toStringSlice(userConfig[0]["ip_filter"].([]interface{}))
-	tf := toStringSlice(tfInt[0].(map[string]interface{})["ip_filter"].([]interface{}))
+// key identifies an IP filter entry regardless of the notation of its network
+func key(entry interface{}) string {
+	k := Canonical(Network(entry))
+	if m, ok := entry.(map[string]interface{}); ok {
+		d, _ := m["description"].(string)
+		k += "\x00" + d
+	}
 
-	var newIpFilters []string
-	var diff []string
+	return k
+}
 
-	// First, we take all the elements from TF and if they match with the data
-	// coming from API we preserve the same order as was defined in manifest
-	for _, t := range tf {
-		found := false
-		if t == "" {
-			continue
+// Equal reports whether two lists of IP filter entries contain the same entries, ignoring their
+// order, duplicates and the notation of the networks
+func Equal(a, b []interface{}) bool {
+	keys := func(l []interface{}) map[string]bool {
+		r := make(map[string]bool, len(l))
+		for _, e := range l {
+			if Network(e) != "" {
+				r[key(e)] = true
+			}
 		}
+		return r
+	}
 
-		for _, a := range api {
-			if t == a {
-				newIpFilters = append(newIpFilters, t)
-				found = true
-				break
-			}
+	ka, kb := keys(a), keys(b)
+	if len(ka) != len(kb) {
+		return false
+	}
+	for k := range ka {
+		if !kb[k] {
+			return false
 		}
+	}
+
+	return true
+}
+
+// IsDefault reports whether a list of IP filter entries is the default of a service
+func IsDefault(l []interface{}) bool {
+	return len(l) == 1 && Canonical(Network(l[0])) == DefaultNetwork
+}
 
-		// TF elements that are missing in API version we add to a diff
-		if !found {
-			diff = append(diff, t)
+// Split splits the IP filter entries returned by the API into the plain networks and the
+// entries with a description
+func Split(entries []interface{}) (networks []interface{}, objects []interface{}) {
+	networks, objects = []interface{}{}, []interface{}{}
+
+	for _, e := range entries {
+		switch v := e.(type) {
+		case string:
+			networks = append(networks, v)
+		case map[string]interface{}:
+			d, _ := v["description"].(string)
+			objects = append(objects, map[string]interface{}{
+				"network":     Network(v),
+				"description": d,
+			})
 		}
 	}
 
-	// Second, we take an API version and only detect whether there is any
-	// difference with the TF version and update diff
-	for _, a := range api {
-		found := false
-		for _, t := range tf {
-			if a == t {
-				found = true
-				break
-			}
+	return networks, objects
+}
+
+// Merge combines the plain networks and the entries with a description into the IP filter sent to
+// the API, removing duplicate networks. Entries with a description take precedence.
+func Merge(networks []interface{}, objects []interface{}) []interface{} {
+	seen := make(map[string]bool)
+	result := make([]interface{}, 0, len(networks)+len(objects))
+
+	for _, o := range objects {
+		m := o.(map[string]interface{})
+		n := Network(m)
+		if n == "" || seen[Canonical(n)] {
+			continue
 		}
+		seen[Canonical(n)] = true
 
-		if !found {
-			diff = append(diff, a)
+		entry := map[string]interface{}{"network": n}
+		if d, _ := m["description"].(string); d != "" {
+			entry["description"] = d
 		}
+		result = append(result, entry)
 	}
 
-	userConfig[0]["ip_filter"] = trim(append(newIpFilters, diff...))
-	return userConfig
+	for _, e := range networks {
+		n := Network(e)
+		if n == "" || seen[Canonical(n)] {
+			continue
+		}
+		seen[Canonical(n)] = true
+		result = append(result, n)
+	}
+
+	return result
 }
 
-// toStringSlice converts []interface to a []string
-func toStringSlice(s []interface{}) []string {
-	if len(s) == 0 {
-		return nil
+// Normalize orders the IP filter entries coming from Aiven the same way as they are defined in
+// the TF manifest and uses the notation of the manifest for equal networks, so that neither the
+// order nor the notation of the networks produces a diff. Entries missing from the manifest
+// follow in the order of the API.
+func Normalize(tfUserConfig interface{}, userConfig []map[string]interface{}) []map[string]interface{} {
+	if len(userConfig) == 0 {
+		return userConfig
+	}
+
+	tfInt, ok := tfUserConfig.([]interface{})
+	if !ok || len(tfInt) == 0 || tfInt[0] == nil {
+		return userConfig
 	}
+	tf := tfInt[0].(map[string]interface{})
 
-	r := make([]string, len(s))
-	for i, e := range s {
-		r[i] = e.(string)
+	if api, ok := userConfig[0]["ip_filter"].([]interface{}); ok {
+		userConfig[0]["ip_filter"] = normalize(toList(tf["ip_filter"]), api)
+	}
+	if api, ok := userConfig[0]["ip_filter_object"].([]interface{}); ok {
+		userConfig[0]["ip_filter_object"] = normalize(toList(tf["ip_filter_object"]), api)
 	}
 
-	return r
+	return userConfig
 }
 
-// trim removes all empty string "" elements from a string slice
-func trim(s []string) []interface{} {
-	var res []interface{}
+// normalize orders api like tf in a single pass over both lists
+func normalize(tf, api []interface{}) []interface{} {
+	pending := make(map[string][]int, len(api))
+	for i, a := range api {
+		pending[key(a)] = append(pending[key(a)], i)
+	}
+
+	used := make([]bool, len(api))
+	result := make([]interface{}, 0, len(api))
+	for _, t := range tf {
+		if Network(t) == "" {
+			continue
+		}
 
-	for _, i := range s {
-		if i != "" {
-			res = append(res, i)
+		indexes := pending[key(t)]
+		if len(indexes) == 0 {
+			continue
 		}
+		pending[key(t)] = indexes[1:]
+		used[indexes[0]] = true
+
+		// the manifest notation of the network is kept
+		result = append(result, t)
 	}
 
-	return res
+	for i, a := range api {
+		if !used[i] && Network(a) != "" {
+			result = append(result, a)
+		}
+	}
+
+	return result
+}
+
+// toList converts a list or a set of the TF manifest to a list
+func toList(v interface{}) []interface{} {
+	switch l := v.(type) {
+	case []interface{}:
+		return l
+	case lister:
+		return l.List()
+	default:
+		return nil
+	}
 }
@@ -0,0 +1,166 @@
+// Copyright (c) 2018-2021 Aiven, Helsinki, Finland. https://aiven.io/
+package ipfilter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCanonical(t *testing.T) {
+	tests := []struct {
+		name    string
+		network string
+		want    string
+	}{
+		{"cidr", "10.20.0.0/16", "10.20.0.0/16"},
+		{"address", "10.0.0.1", "10.0.0.1/32"},
+		{"host bits", "10.20.1.2/16", "10.20.0.0/16"},
+		{"ipv6 address", "2001:db8::1", "2001:db8::1/128"},
+		{"spaces", " 10.0.0.1/32 ", "10.0.0.1/32"},
+		{"invalid", "foo", "foo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Canonical(tt.network); got != tt.want {
+				t.Errorf("Canonical() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		name string
+		a    []interface{}
+		b    []interface{}
+		want bool
+	}{
+		{
+			"same",
+			[]interface{}{"10.0.0.0/8", "192.168.0.1/32"},
+			[]interface{}{"10.0.0.0/8", "192.168.0.1/32"},
+			true,
+		},
+		{
+			"order",
+			[]interface{}{"10.0.0.0/8", "192.168.0.1/32"},
+			[]interface{}{"192.168.0.1/32", "10.0.0.0/8"},
+			true,
+		},
+		{
+			"notation and duplicates",
+			[]interface{}{"10.0.0.1", "10.0.0.1/32"},
+			[]interface{}{"10.0.0.1/32"},
+			true,
+		},
+		{
+			"objects",
+			[]interface{}{map[string]interface{}{"network": "10.0.0.1", "description": "foo"}},
+			[]interface{}{map[string]interface{}{"network": "10.0.0.1/32", "description": "foo"}},
+			true,
+		},
+		{
+			"description",
+			[]interface{}{map[string]interface{}{"network": "10.0.0.1", "description": "foo"}},
+			[]interface{}{map[string]interface{}{"network": "10.0.0.1", "description": "bar"}},
+			false,
+		},
+		{
+			"different",
+			[]interface{}{"10.0.0.0/8"},
+			[]interface{}{"10.0.0.0/16"},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Equal(tt.a, tt.b); got != tt.want {
+				t.Errorf("Equal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeSplit(t *testing.T) {
+	networks := []interface{}{"10.0.0.1", "192.168.0.0/16", "192.168.0.0/16"}
+	objects := []interface{}{map[string]interface{}{"network": "10.0.0.1/32", "description": "office"}}
+
+	merged := Merge(networks, objects)
+	want := []interface{}{
+		map[string]interface{}{"network": "10.0.0.1/32", "description": "office"},
+		"192.168.0.0/16",
+	}
+	if !reflect.DeepEqual(merged, want) {
+		t.Fatalf("Merge() = %v, want %v", merged, want)
+	}
+
+	gotNetworks, gotObjects := Split(merged)
+	if !reflect.DeepEqual(gotNetworks, []interface{}{"192.168.0.0/16"}) {
+		t.Errorf("Split() networks = %v", gotNetworks)
+	}
+	if !reflect.DeepEqual(gotObjects, objects) {
+		t.Errorf("Split() objects = %v, want %v", gotObjects, objects)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name       string
+		tf         interface{}
+		userConfig []map[string]interface{}
+		want       []map[string]interface{}
+	}{
+		{
+			"order of the manifest",
+			[]interface{}{map[string]interface{}{"ip_filter": []interface{}{"10.0.0.0/8", "1.1.1.1/32"}}},
+			[]map[string]interface{}{{"ip_filter": []interface{}{"1.1.1.1/32", "10.0.0.0/8"}}},
+			[]map[string]interface{}{{"ip_filter": []interface{}{"10.0.0.0/8", "1.1.1.1/32"}}},
+		},
+		{
+			"notation of the manifest",
+			[]interface{}{map[string]interface{}{"ip_filter": []interface{}{"10.0.0.1", "10.0.0.0/8"}}},
+			[]map[string]interface{}{{"ip_filter": []interface{}{"10.0.0.0/8", "10.0.0.1/32"}}},
+			[]map[string]interface{}{{"ip_filter": []interface{}{"10.0.0.1", "10.0.0.0/8"}}},
+		},
+		{
+			"missing from the manifest",
+			[]interface{}{map[string]interface{}{"ip_filter": []interface{}{"10.0.0.0/8", "", "2.2.2.2/32"}}},
+			[]map[string]interface{}{{"ip_filter": []interface{}{"1.1.1.1/32", "10.0.0.0/8"}}},
+			[]map[string]interface{}{{"ip_filter": []interface{}{"10.0.0.0/8", "1.1.1.1/32"}}},
+		},
+		{
+			"duplicates in the manifest",
+			[]interface{}{map[string]interface{}{"ip_filter": []interface{}{"10.0.0.0/8", "10.0.0.0/8"}}},
+			[]map[string]interface{}{{"ip_filter": []interface{}{"10.0.0.0/8"}}},
+			[]map[string]interface{}{{"ip_filter": []interface{}{"10.0.0.0/8"}}},
+		},
+		{
+			"objects",
+			[]interface{}{map[string]interface{}{"ip_filter_object": []interface{}{
+				map[string]interface{}{"network": "10.0.0.1", "description": "foo"},
+				map[string]interface{}{"network": "10.0.0.0/8", "description": "bar"},
+			}}},
+			[]map[string]interface{}{{"ip_filter_object": []interface{}{
+				map[string]interface{}{"network": "10.0.0.0/8", "description": "bar"},
+				map[string]interface{}{"network": "10.0.0.1/32", "description": "foo"},
+			}}},
+			[]map[string]interface{}{{"ip_filter_object": []interface{}{
+				map[string]interface{}{"network": "10.0.0.1", "description": "foo"},
+				map[string]interface{}{"network": "10.0.0.0/8", "description": "bar"},
+			}}},
+		},
+		{
+			"no manifest",
+			[]interface{}{},
+			[]map[string]interface{}{{"ip_filter": []interface{}{"1.1.1.1/32", "10.0.0.0/8"}}},
+			[]map[string]interface{}{{"ip_filter": []interface{}{"1.1.1.1/32", "10.0.0.0/8"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Normalize(tt.tf, tt.userConfig); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Normalize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}